@@ -0,0 +1,65 @@
+// Package bench provides standard benchmarks for greet.Greeter
+// implementations.
+package bench
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/greet"
+)
+
+// Run runs the standard greeting benchmarks against g as sub-benchmarks of
+// b, reporting allocations for each. Run only reports: budgets depend on
+// the Greeter, so enforce one with testing.AllocsPerRun in a test, as this
+// package's tests do for SimpleGreeter. Call Run from a Benchmark function
+// to compare Greeter implementations:
+//
+//	func BenchmarkMyGreeter(b *testing.B) {
+//		bench.Run(b, myGreeter)
+//	}
+func Run(b *testing.B, g greet.Greeter) {
+	b.Run("Greet", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			g.Greet()
+		}
+	})
+
+	for _, n := range []int{100, 1000, 10000} {
+		names := make([]string, n)
+		for i := range names {
+			names[i] = "name" + strconv.Itoa(i)
+		}
+		b.Run("GreetAll/"+strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				greet.GreetAll(names, greet.WithGreeter(g))
+			}
+		})
+	}
+
+	b.Run("Middleware/5", func(b *testing.B) {
+		chained := greet.Chain(g,
+			greet.TrimSpaceMiddleware,
+			greet.PrefixMiddleware("[bench] "),
+			greet.UppercaseMiddleware,
+			greet.PrefixMiddleware("> "),
+			greet.TrimSpaceMiddleware,
+		)
+		b.ReportAllocs()
+		for range b.N {
+			chained.Greet()
+		}
+	})
+
+	b.Run("Template", func(b *testing.B) {
+		const tmpl = "Hello {{.Name}} ({{.Locale}})"
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := greet.GreetTemplate("Alice", tmpl); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
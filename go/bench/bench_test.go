@@ -0,0 +1,42 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/greet"
+)
+
+func BenchmarkSimpleGreeter(b *testing.B) {
+	Run(b, greet.SimpleGreeter{Name: "Alice"})
+}
+
+// TestSimpleGreeterAllocs fails when a change makes the benchmarked
+// operations allocate more than they do today. Template has room for one
+// more allocation: the standard library's sync.Pool caches used while
+// rendering drop items at random under the race detector.
+func TestSimpleGreeterAllocs(t *testing.T) {
+	g := greet.SimpleGreeter{Name: "Alice"}
+	chained := greet.Chain(g,
+		greet.TrimSpaceMiddleware,
+		greet.PrefixMiddleware("[bench] "),
+		greet.UppercaseMiddleware,
+		greet.PrefixMiddleware("> "),
+		greet.TrimSpaceMiddleware,
+	)
+	names := []string{"Ada", "Grace", "Alan"}
+	tests := []struct {
+		name string
+		f    func()
+		max  float64
+	}{
+		{"Greet", func() { g.Greet() }, 6},
+		{"Middleware/5", func() { chained.Greet() }, 9},
+		{"Template", func() { greet.GreetTemplate("Alice", "Hello {{.Name}} ({{.Locale}})") }, 7},
+		{"GreetAll/3", func() { greet.GreetAll(names, greet.WithGreeter(g), greet.WithConcurrency(1)) }, 30},
+	}
+	for _, tt := range tests {
+		if got := testing.AllocsPerRun(100, tt.f); got > tt.max {
+			t.Errorf("%s: %v allocs per run, want at most %v", tt.name, got, tt.max)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package cli implements the greet command line tool.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/greet"
+)
+
+const cliUsage = `Usage: greet --name NAME [--name NAME...] [flags]
+
+Prints a greeting for each name.
+
+Flags:
+`
+
+const cliExamples = `
+Examples:
+  greet --name Ada
+  greet --name Curie --salutation Dr. --locale fr
+  greet --name Ada --name Grace --format json
+  greet --name Ada --template 'Welcome back, {{.Name}}!'
+`
+
+// Run is the greet command line tool. It parses args, writes greetings to
+// stdout and errors to stderr, and returns the process exit code: 0 on
+// success, 1 for an invalid name, format or template, and 2 for bad
+// flags.
+func Run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("greet", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var names cliNames
+	fs.Var(&names, "name", "name to greet; repeat for several names")
+	locale := fs.String("locale", greet.DefaultLocale, "IETF language tag of the greeting")
+	salutation := fs.String("salutation", "", "title placed before each name, such as Dr.")
+	format := fs.String("format", "plain", "output format: plain, json or markdown")
+	tmpl := fs.String("template", "", "text/template for the greeting, using {{.Name}}, {{.Locale}} and {{.Time}}")
+	fs.Usage = func() {
+		fmt.Fprint(stderr, cliUsage)
+		fs.PrintDefaults()
+		fmt.Fprint(stderr, cliExamples)
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "greet: unexpected argument %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(stderr, "greet: at least one --name is required")
+		return 1
+	}
+
+	opts := []greet.Option{greet.WithLocale(*locale), greet.WithSalutation(*salutation)}
+	switch *format {
+	case "plain", "json":
+	case "markdown":
+		opts = append(opts, greet.WithFormat(greet.FormatMarkdown))
+	default:
+		fmt.Fprintf(stderr, "greet: unknown format %q\n", *format)
+		return 1
+	}
+
+	type result struct {
+		Name     string `json:"name"`
+		Greeting string `json:"greeting"`
+	}
+	results := make([]result, 0, len(names))
+	for _, name := range names {
+		g, err := greet.NewGreeter(name, opts...)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		g.Template = *tmpl
+		s, err := g.GreetContext(context.Background(), name)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		results = append(results, result{Name: name, Greeting: s})
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(results)
+		return 0
+	}
+	for _, r := range results {
+		fmt.Fprintln(stdout, r.Greeting)
+	}
+	return 0
+}
+
+// cliNames is a repeatable string flag.
+type cliNames []string
+
+func (n *cliNames) String() string {
+	return strings.Join(*n, ",")
+}
+
+func (n *cliNames) Set(s string) error {
+	*n = append(*n, s)
+	return nil
+}
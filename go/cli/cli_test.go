@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "one name",
+			args:       []string{"--name", "Ada"},
+			wantStdout: "Hello Ada\n",
+		},
+		{
+			name:       "repeated name",
+			args:       []string{"--name", "Ada", "--name=Grace", "-salutation", "Dr.", "--locale", "fr"},
+			wantStdout: "Bonjour Dr. Ada\nBonjour Dr. Grace\n",
+		},
+		{
+			name:       "markdown",
+			args:       []string{"--name", "Ada", "--format", "markdown"},
+			wantStdout: "Hello **Ada**\n",
+		},
+		{
+			name:       "template",
+			args:       []string{"--name", "Ada", "--template", "Welcome back, {{.Name}}!"},
+			wantStdout: "Welcome back, Ada!\n",
+		},
+		{
+			name:       "no name",
+			wantCode:   1,
+			wantStderr: "greet: at least one --name is required\n",
+		},
+		{
+			name:       "empty name",
+			args:       []string{"--name", "Ada", "--name", " "},
+			wantCode:   1,
+			wantStderr: "greet: empty name\n",
+		},
+		{
+			name:       "unknown format",
+			args:       []string{"--name", "Ada", "--format", "xml"},
+			wantCode:   1,
+			wantStderr: "greet: unknown format \"xml\"\n",
+		},
+		{
+			name:     "unparsable template",
+			args:     []string{"--name", "Ada", "--template", "{{.Name"},
+			wantCode: 1,
+		},
+		{
+			name:     "failing template",
+			args:     []string{"--name", "Ada", "--template", "{{.Missing}}"},
+			wantCode: 1,
+		},
+		{
+			name:     "unknown flag",
+			args:     []string{"--nmae", "Ada"},
+			wantCode: 2,
+		},
+		{
+			name:       "stray argument",
+			args:       []string{"--name", "Ada", "Grace"},
+			wantCode:   2,
+			wantStderr: "greet: unexpected argument \"Grace\"\n",
+		},
+	}
+	for _, tt := range tests {
+		var stdout, stderr strings.Builder
+		code := Run(tt.args, &stdout, &stderr)
+		if code != tt.wantCode {
+			t.Errorf("%s: Run(%q) = %d, want %d; stderr: %s", tt.name, tt.args, code, tt.wantCode, stderr.String())
+		}
+		if got := stdout.String(); got != tt.wantStdout {
+			t.Errorf("%s: stdout = %q, want %q", tt.name, got, tt.wantStdout)
+		}
+		if tt.wantCode != 0 && stderr.Len() == 0 {
+			t.Errorf("%s: nothing written to stderr", tt.name)
+		}
+		if tt.wantStderr != "" && !strings.HasPrefix(stderr.String(), tt.wantStderr) {
+			t.Errorf("%s: stderr = %q, want it to start with %q", tt.name, stderr.String(), tt.wantStderr)
+		}
+		if tt.wantCode == 0 && stderr.Len() != 0 {
+			t.Errorf("%s: stderr = %q, want nothing", tt.name, stderr.String())
+		}
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	var stdout, stderr strings.Builder
+	if code := Run([]string{"--name", "Ada", "--name", "Grace", "--format", "json", "--locale", "de"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run = %d; stderr: %s", code, stderr.String())
+	}
+	var got []map[string]string
+	if err := json.Unmarshal([]byte(stdout.String()), &got); err != nil {
+		t.Fatalf("stdout %q is not a JSON array: %v", stdout.String(), err)
+	}
+	want := []map[string]string{
+		{"name": "Ada", "greeting": "Hallo Ada"},
+		{"name": "Grace", "greeting": "Hallo Grace"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d results, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if len(got[i]) != 2 || got[i]["name"] != want[i]["name"] || got[i]["greeting"] != want[i]["greeting"] {
+			t.Errorf("result %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRunHelp(t *testing.T) {
+	for _, arg := range []string{"--help", "-h"} {
+		var stdout, stderr strings.Builder
+		if code := Run([]string{arg}, &stdout, &stderr); code != 0 {
+			t.Errorf("Run(%s) = %d, want 0", arg, code)
+		}
+		help := stderr.String()
+		for _, want := range []string{"Usage: greet", "-name", "-format", "Examples:\n", "greet --name Ada --name Grace --format json"} {
+			if !strings.Contains(help, want) {
+				t.Errorf("Run(%s) help does not contain %q:\n%s", arg, want, help)
+			}
+		}
+	}
+}
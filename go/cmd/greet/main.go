@@ -0,0 +1,13 @@
+// Command greet prints greetings from the command line. Run greet --help
+// for usage.
+package main
+
+import (
+	"os"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
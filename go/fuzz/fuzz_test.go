@@ -0,0 +1,56 @@
+//go:build gofuzz
+
+// Package fuzz holds the fuzz targets for package greet. Run them with
+//
+//	go test -tags gofuzz -fuzz FuzzGreet ./fuzz
+package fuzz
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/greet"
+)
+
+// fuzzNames seeds the name corpus with inputs that have caused trouble for
+// callers passing untrusted names straight to Greet.
+var fuzzNames = []string{
+	"",
+	"Alice",
+	strings.Repeat("a", 1<<16),
+	"\x00\x01\x1b[31m\x7f",
+	"‮ecilA",
+	"👋🏽 Zoë",
+	"\xff\xfe",
+}
+
+// FuzzGreet checks that Greet never panics and always returns valid UTF-8.
+func FuzzGreet(f *testing.F) {
+	for _, name := range fuzzNames {
+		f.Add(name)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		if s := greet.Greet(name); !utf8.ValidString(s) {
+			t.Errorf("Greet(%q) = %q, not valid UTF-8", name, s)
+		}
+	})
+}
+
+// FuzzGreetTemplate checks that GreetTemplate never panics and returns
+// either valid UTF-8 or an error for arbitrary names and templates.
+func FuzzGreetTemplate(f *testing.F) {
+	for _, name := range fuzzNames {
+		f.Add(name, "Hello {{.Name}}")
+	}
+	f.Add("Alice", "{{")
+	f.Add("Alice", "{{.Missing}}")
+	f.Add("Alice", "{{.Time.Year}} {{.Locale}}")
+	f.Add("Alice", "\xff{{.Name}}")
+	f.Fuzz(func(t *testing.T, name, tmpl string) {
+		s, err := greet.GreetTemplate(name, tmpl)
+		if err == nil && !utf8.ValidString(s) {
+			t.Errorf("GreetTemplate(%q, %q) = %q, not valid UTF-8", name, tmpl, s)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package greet
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Annotation keys understood by WithAnnotations.
+const (
+	// AnnotationTraceID is the trace ID stored with ContextWithTraceID.
+	AnnotationTraceID = "trace_id"
+	// AnnotationLocale is the resolved locale tag.
+	AnnotationLocale = "locale"
+	// AnnotationTemplateHash is the FNV-1a hash, in hex, of the template
+	// the greeting was rendered from.
+	AnnotationTemplateHash = "template_hash"
+	// AnnotationGeneratedAt is the generation time in RFC 3339 format, UTC.
+	AnnotationGeneratedAt = "generated_at"
+)
+
+// AnnotatedGreeting is a greeting together with metadata for tracing.
+type AnnotatedGreeting struct {
+	Greeting    string
+	Annotations map[string]string
+}
+
+// String returns the greeting text alone.
+func (a AnnotatedGreeting) String() string {
+	return a.Greeting
+}
+
+// WithAnnotations selects which annotation keys GreetAnnotated populates.
+// Unknown keys are ignored.
+func WithAnnotations(keys ...string) Option {
+	return func(g *SimpleGreeter) error {
+		g.annotations = slices.Clone(keys)
+		return nil
+	}
+}
+
+// GreetAnnotated greets name like Greet and attaches the annotations
+// selected with WithAnnotations.
+func (g SimpleGreeter) GreetAnnotated(name string) AnnotatedGreeting {
+	g.Name = name
+	return g.annotate(context.Background(), g.Greet())
+}
+
+// GreetAnnotatedContext greets name like GreetContext, also annotating the
+// greeting with the trace ID stored in ctx.
+func (g SimpleGreeter) GreetAnnotatedContext(ctx context.Context, name string) (AnnotatedGreeting, error) {
+	s, err := g.GreetContext(ctx, name)
+	if err != nil {
+		return AnnotatedGreeting{}, err
+	}
+	return g.forContext(ctx, name).annotate(ctx, s), nil
+}
+
+func (g SimpleGreeter) annotate(ctx context.Context, greeting string) AnnotatedGreeting {
+	a := AnnotatedGreeting{Greeting: greeting}
+	if len(g.annotations) == 0 {
+		return a
+	}
+	a.Annotations = make(map[string]string, len(g.annotations))
+	t, tag, _ := lookupLocale(g.Locale)
+	for _, key := range g.annotations {
+		switch key {
+		case AnnotationTraceID:
+			if id, ok := TraceIDFromContext(ctx); ok {
+				a.Annotations[key] = id
+			}
+		case AnnotationLocale:
+			a.Annotations[key] = tag
+		case AnnotationTemplateHash:
+			src := g.Template
+			if src == "" {
+				src = t.Root.String()
+			}
+			h := fnv.New64a()
+			h.Write([]byte(src))
+			a.Annotations[key] = strconv.FormatUint(h.Sum64(), 16)
+		case AnnotationGeneratedAt:
+			a.Annotations[key] = time.Now().UTC().Format(time.RFC3339Nano)
+		}
+	}
+	return a
+}
@@ -0,0 +1,88 @@
+package greet
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGreetAnnotatedContext(t *testing.T) {
+	const tmpl = "Hi {{.Name}}"
+	g, err := NewGreeter("Eve", WithLocale("fr-CA"), WithAnnotations(
+		AnnotationTraceID, AnnotationLocale, AnnotationTemplateHash, AnnotationGeneratedAt, "unknown",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Template = tmpl
+	ctx := ContextWithTraceID(context.Background(), "trace-123")
+	before := time.Now().UTC()
+	a, err := g.GreetAnnotatedContext(ctx, "Ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Greeting != "Hi Ada" || a.String() != "Hi Ada" {
+		t.Errorf("greeting = %q, want Hi Ada", a.Greeting)
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(tmpl))
+	want := map[string]string{
+		AnnotationTraceID:      "trace-123",
+		AnnotationLocale:       "fr",
+		AnnotationTemplateHash: strconv.FormatUint(h.Sum64(), 16),
+	}
+	for key, v := range want {
+		if got := a.Annotations[key]; got != v {
+			t.Errorf("annotation %s = %q, want %q", key, got, v)
+		}
+	}
+	at, err := time.Parse(time.RFC3339Nano, a.Annotations[AnnotationGeneratedAt])
+	if err != nil || at.Before(before) || at.After(time.Now().UTC()) || at.Location() != time.UTC {
+		t.Errorf("generated_at = %q, want the UTC generation time", a.Annotations[AnnotationGeneratedAt])
+	}
+	if len(a.Annotations) != 4 {
+		t.Errorf("annotations = %v, want only the known keys", a.Annotations)
+	}
+}
+
+func TestGreetAnnotated(t *testing.T) {
+	g, err := NewGreeter("Eve", WithAnnotations(AnnotationTraceID, AnnotationLocale, AnnotationTemplateHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := g.GreetAnnotated("Ada")
+	if a.Greeting != "Hello Ada" {
+		t.Errorf("greeting = %q, want Hello Ada", a.Greeting)
+	}
+	if _, ok := a.Annotations[AnnotationTraceID]; ok {
+		t.Errorf("trace_id = %q without a trace in context, want it omitted", a.Annotations[AnnotationTraceID])
+	}
+	if a.Annotations[AnnotationLocale] != DefaultLocale {
+		t.Errorf("locale = %q, want %q", a.Annotations[AnnotationLocale], DefaultLocale)
+	}
+	// Locale templates are hashed too, and differ between locales.
+	fr := *g
+	fr.Locale = "fr"
+	if a.Annotations[AnnotationTemplateHash] == "" || a.Annotations[AnnotationTemplateHash] == fr.GreetAnnotated("Ada").Annotations[AnnotationTemplateHash] {
+		t.Errorf("template_hash = %q, want a non-empty hash of the locale template", a.Annotations[AnnotationTemplateHash])
+	}
+
+	if a := (SimpleGreeter{}).GreetAnnotated("Ada"); a.Annotations != nil {
+		t.Errorf("annotations without WithAnnotations = %v, want nil", a.Annotations)
+	}
+}
+
+func TestGreetAnnotatedContextError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g, err := NewGreeter("Eve", WithAnnotations(AnnotationLocale))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, err := g.GreetAnnotatedContext(ctx, "Ada"); err != context.Canceled || a.Greeting != "" || a.Annotations != nil {
+		t.Errorf("GreetAnnotatedContext with a done context = %+v, %v; want context.Canceled", a, err)
+	}
+}
@@ -0,0 +1,135 @@
+package greet
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AuditLogger is a Greeter that appends a JSON line to a writer for every
+// greeting it produces, recording when, for whom and from where in the
+// program it was requested.
+type AuditLogger struct {
+	g  Greeter
+	mu sync.Mutex
+	w  io.Writer
+}
+
+var _ GreeterWithError = (*AuditLogger)(nil)
+
+// auditRecord is one line of the audit trail.
+type auditRecord struct {
+	TS       string `json:"ts"`
+	Name     string `json:"name"`
+	Greeting string `json:"greeting"`
+	Caller   string `json:"caller"`
+}
+
+// NewAuditLogger returns g with every greeting recorded to w. Writes are
+// serialized, and w is flushed after each record if it is an http.Flusher
+// or a *bufio.Writer.
+func NewAuditLogger(w io.Writer, g Greeter) *AuditLogger {
+	return &AuditLogger{g: g, w: w}
+}
+
+// NewAuditFileLogger is like NewAuditLogger but appends to the file at
+// path, creating it if needed. Close the returned logger to close the
+// file.
+func NewAuditFileLogger(path string, g Greeter) (*AuditLogger, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuditLogger(f, g), nil
+}
+
+// Greet greets with the wrapped greeter and records it. The recorded name
+// is the greeter's Name for a SimpleGreeter and empty otherwise. It
+// returns "" if the record cannot be written.
+func (a *AuditLogger) Greet() string {
+	s := a.g.Greet()
+	var name string
+	switch v := a.g.(type) {
+	case SimpleGreeter:
+		name = v.Name
+	case *SimpleGreeter:
+		name = v.Name
+	}
+	if err := a.record(name, s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// GreetContext greets name with the wrapped greeter and records it. A
+// greeting that cannot be recorded is not returned; the write error is
+// returned instead.
+func (a *AuditLogger) GreetContext(ctx context.Context, name string) (string, error) {
+	s, err := GreetName(ctx, a.g, name)
+	if err != nil {
+		return "", err
+	}
+	if err := a.record(name, s); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (a *AuditLogger) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if c, ok := a.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// record writes an audit line. It must be called directly from Greet or
+// GreetContext so that the caller recorded is theirs.
+func (a *AuditLogger) record(name, greeting string) error {
+	data, err := json.Marshal(auditRecord{
+		TS:       time.Now().UTC().Format(time.RFC3339Nano),
+		Name:     name,
+		Greeting: greeting,
+		Caller:   callerOf(3),
+	})
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(data); err != nil {
+		return fmt.Errorf("greet: audit: %w", err)
+	}
+	switch f := a.w.(type) {
+	case http.Flusher:
+		f.Flush()
+	case *bufio.Writer:
+		if err := f.Flush(); err != nil {
+			return fmt.Errorf("greet: audit: %w", err)
+		}
+	}
+	return nil
+}
+
+// callerOf returns the file:line of the function skip frames above its
+// caller, as counted by runtime.Callers.
+func callerOf(skip int) string {
+	var pc [1]uintptr
+	if runtime.Callers(skip+1, pc[:]) == 0 {
+		return ""
+	}
+	f, _ := runtime.CallersFrames(pc[:]).Next()
+	return f.File + ":" + strconv.Itoa(f.Line)
+}
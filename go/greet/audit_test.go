@@ -0,0 +1,53 @@
+package greet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAuditLoggerRecordsName(t *testing.T) {
+	ptr, err := NewGreeter("Ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		desc string
+		g    Greeter
+	}{
+		{"value", SimpleGreeter{Name: "Ada"}},
+		{"pointer", ptr},
+	} {
+		var buf bytes.Buffer
+		if got := NewAuditLogger(&buf, tc.g).Greet(); got != "Hello Ada" {
+			t.Errorf("%s: Greet = %q, want %q", tc.desc, got, "Hello Ada")
+		}
+		var rec auditRecord
+		if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+			t.Fatalf("%s: audit line %q: %v", tc.desc, buf.String(), err)
+		}
+		if rec.Name != "Ada" || rec.Greeting != "Hello Ada" {
+			t.Errorf("%s: recorded name, greeting = %q, %q; want Ada, Hello Ada", tc.desc, rec.Name, rec.Greeting)
+		}
+		if !strings.Contains(rec.Caller, "audit_test.go:") {
+			t.Errorf("%s: caller = %q, want this test file", tc.desc, rec.Caller)
+		}
+	}
+}
+
+func TestAuditLoggerGreetContext(t *testing.T) {
+	var buf bytes.Buffer
+	a := NewAuditLogger(&buf, SimpleGreeter{})
+	if _, err := a.GreetContext(context.Background(), "Grace"); err != nil {
+		t.Fatal(err)
+	}
+	var rec auditRecord
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Name != "Grace" || rec.TS == "" {
+		t.Errorf("record = %+v, want name Grace and a timestamp", rec)
+	}
+}
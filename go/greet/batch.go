@@ -0,0 +1,88 @@
+package greet
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+type batchConfig struct {
+	concurrency int
+	greeter     Greeter
+}
+
+// BatchOption configures GreetAll and GreetAllContext.
+type BatchOption func(*batchConfig)
+
+// WithConcurrency caps the number of worker goroutines. Values below one
+// are ignored.
+func WithConcurrency(n int) BatchOption {
+	return func(c *batchConfig) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithGreeter greets the batch with g instead of Greet. See
+// GreeterWithError for how g receives each name.
+func WithGreeter(g Greeter) BatchOption {
+	return func(c *batchConfig) {
+		c.greeter = g
+	}
+}
+
+// GreetAll greets every name using a pool of workers, runtime.NumCPU() by
+// default. The result is in input order and never nil.
+func GreetAll(names []string, opts ...BatchOption) []string {
+	out, _ := GreetAllContext(context.Background(), names, opts...)
+	return out
+}
+
+// GreetAllContext is like GreetAll but stops handing out work once ctx is
+// done, returning the greetings produced so far along with ctx.Err().
+// Entries that were not greeted, or whose greeter failed, are left empty.
+func GreetAllContext(ctx context.Context, names []string, opts ...BatchOption) ([]string, error) {
+	cfg := batchConfig{concurrency: runtime.NumCPU()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	out := make([]string, len(names))
+	if len(names) == 0 {
+		return out, nil
+	}
+	workers := min(cfg.concurrency, len(names))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if cfg.greeter == nil {
+					out[i] = Greet(names[i])
+				} else {
+					out[i], _ = GreetName(ctx, cfg.greeter, names[i])
+				}
+			}
+		}()
+	}
+
+	var err error
+feed:
+	for i := range names {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return out, err
+}
@@ -0,0 +1,114 @@
+package greet
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGreetAllOrder(t *testing.T) {
+	names := make([]string, 200)
+	for i := range names {
+		names[i] = "n" + strconv.Itoa(i)
+	}
+	// Early names take longest, so workers finish out of order.
+	slow := contextGreeter(func(ctx context.Context, name string) (string, error) {
+		i, _ := strconv.Atoi(name[1:])
+		time.Sleep(time.Duration(len(names)-i) * time.Microsecond)
+		return "Hi " + name, nil
+	})
+	for _, opts := range [][]BatchOption{nil, {WithConcurrency(8), WithGreeter(slow)}} {
+		got := GreetAll(names, opts...)
+		if len(got) != len(names) {
+			t.Fatalf("GreetAll returned %d greetings, want %d", len(got), len(names))
+		}
+		prefix := "Hello "
+		if opts != nil {
+			prefix = "Hi "
+		}
+		for i, s := range got {
+			if want := prefix + names[i]; s != want {
+				t.Errorf("greeting %d = %q, want %q", i, s, want)
+				break
+			}
+		}
+	}
+}
+
+func TestGreetAllEmpty(t *testing.T) {
+	for _, names := range [][]string{nil, {}} {
+		if got := GreetAll(names); got == nil || len(got) != 0 {
+			t.Errorf("GreetAll(%#v) = %#v, want an empty non-nil slice", names, got)
+		}
+		got, err := GreetAllContext(context.Background(), names)
+		if got == nil || len(got) != 0 || err != nil {
+			t.Errorf("GreetAllContext(%#v) = %#v, %v; want an empty non-nil slice", names, got, err)
+		}
+	}
+}
+
+func TestWithConcurrency(t *testing.T) {
+	for _, n := range []int{1, 3} {
+		var inFlight, peak atomic.Int32
+		g := contextGreeter(func(ctx context.Context, name string) (string, error) {
+			cur := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if cur <= p || peak.CompareAndSwap(p, cur) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return name, nil
+		})
+		GreetAll(make([]string, 30), WithConcurrency(n), WithGreeter(g))
+		if p := peak.Load(); p > int32(n) {
+			t.Errorf("WithConcurrency(%d): %d greetings in flight", n, p)
+		}
+	}
+}
+
+func TestGreetAllContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	greeted := 0
+	g := contextGreeter(func(ctx context.Context, name string) (string, error) {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if greeted++; greeted == 5 {
+			cancel()
+		}
+		return "Hi " + name, nil
+	})
+	names := make([]string, 100)
+	for i := range names {
+		names[i] = strconv.Itoa(i)
+	}
+	got, err := GreetAllContext(ctx, names, WithConcurrency(1), WithGreeter(g))
+	if err != context.Canceled {
+		t.Errorf("GreetAllContext after cancel = %v, want context.Canceled", err)
+	}
+	if len(got) != len(names) {
+		t.Fatalf("partial result has %d entries, want %d", len(got), len(names))
+	}
+	for i, s := range got {
+		if want := "Hi " + names[i]; i < 5 && s != want {
+			t.Errorf("greeting %d = %q, want %q", i, s, want)
+		}
+		if i >= 5 && s != "" {
+			t.Errorf("greeting %d = %q after cancel, want empty", i, s)
+		}
+	}
+
+	if got, err := GreetAllContext(ctx, names); err != context.Canceled || len(got) != len(names) || got[0] != "" {
+		t.Errorf("GreetAllContext with a done context = %d entries, %v; want nothing greeted", len(got), err)
+	}
+}
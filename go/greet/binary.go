@@ -0,0 +1,166 @@
+package greet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler using encoding/gob.
+func (g SimpleGreeter) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(g.config()); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (g *SimpleGreeter) UnmarshalBinary(data []byte) error {
+	var c greeterConfig
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&c); err != nil {
+		return err
+	}
+	g.setConfig(c)
+	return nil
+}
+
+// protoVersion is the current version of the MarshalProto format. See
+// "Binary format" in the package documentation.
+const protoVersion = 1
+
+// Field tags of the MarshalProto format. Tags are never reused.
+const (
+	protoName = iota + 1
+	protoLocale
+	protoSalutation
+	protoSuffix
+	protoTemplate
+	protoRandomPhrase
+	protoConjunction
+	protoMaxNameLength
+	protoTimeConfig
+	protoFormat
+)
+
+// MarshalProto encodes g in the compact format described under "Binary
+// format" in the package documentation.
+func (g SimpleGreeter) MarshalProto() ([]byte, error) {
+	b := []byte{protoVersion}
+	str := func(tag uint64, s string) {
+		if s != "" {
+			b = appendField(b, tag, []byte(s))
+		}
+	}
+	str(protoName, g.Name)
+	str(protoLocale, g.Locale)
+	str(protoSalutation, g.Salutation)
+	str(protoSuffix, g.Suffix)
+	str(protoTemplate, g.Template)
+	for _, p := range g.RandomPhrases {
+		b = appendField(b, protoRandomPhrase, []byte(p))
+	}
+	str(protoConjunction, g.Conjunction)
+	if g.MaxNameLength != 0 {
+		b = appendField(b, protoMaxNameLength, binary.AppendVarint(nil, int64(g.MaxNameLength)))
+	}
+	if g.Format != FormatPlain {
+		b = appendField(b, protoFormat, binary.AppendVarint(nil, int64(g.Format)))
+	}
+	if tc := g.TimeConfig; tc != nil {
+		v := binary.AppendVarint(nil, int64(tc.MorningStart))
+		v = binary.AppendVarint(v, int64(tc.AfternoonStart))
+		v = binary.AppendVarint(v, int64(tc.EveningStart))
+		b = appendField(b, protoTimeConfig, v)
+	}
+	return b, nil
+}
+
+// UnmarshalProto decodes data produced by MarshalProto into g.
+func (g *SimpleGreeter) UnmarshalProto(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("greet: proto: %w", io.ErrUnexpectedEOF)
+	}
+	if data[0] != protoVersion {
+		return fmt.Errorf("greet: proto: unsupported version %d", data[0])
+	}
+	var out SimpleGreeter
+	r := bytes.NewReader(data[1:])
+	for r.Len() > 0 {
+		tag, err := binary.ReadUvarint(r)
+		if err != nil {
+			return fmt.Errorf("greet: proto: tag: %w", err)
+		}
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return fmt.Errorf("greet: proto: length: %w", err)
+		}
+		if n > uint64(r.Len()) {
+			return fmt.Errorf("greet: proto: field %d: %w", tag, io.ErrUnexpectedEOF)
+		}
+		v := make([]byte, n)
+		r.Read(v)
+
+		switch tag {
+		case protoName:
+			out.Name = string(v)
+		case protoLocale:
+			out.Locale = string(v)
+		case protoSalutation:
+			out.Salutation = string(v)
+		case protoSuffix:
+			out.Suffix = string(v)
+		case protoTemplate:
+			out.Template = string(v)
+		case protoRandomPhrase:
+			out.RandomPhrases = append(out.RandomPhrases, string(v))
+		case protoConjunction:
+			out.Conjunction = string(v)
+		case protoMaxNameLength:
+			ints, err := readVarints(v, 1)
+			if err != nil {
+				return fmt.Errorf("greet: proto: max name length: %w", err)
+			}
+			out.MaxNameLength = int(ints[0])
+		case protoFormat:
+			ints, err := readVarints(v, 1)
+			if err != nil {
+				return fmt.Errorf("greet: proto: format: %w", err)
+			}
+			out.Format = Format(ints[0])
+		case protoTimeConfig:
+			ints, err := readVarints(v, 3)
+			if err != nil {
+				return fmt.Errorf("greet: proto: time config: %w", err)
+			}
+			out.TimeConfig = &TimeGreeterConfig{
+				MorningStart:   int(ints[0]),
+				AfternoonStart: int(ints[1]),
+				EveningStart:   int(ints[2]),
+			}
+		}
+	}
+	g.setConfig(out.config())
+	return nil
+}
+
+func appendField(b []byte, tag uint64, v []byte) []byte {
+	b = binary.AppendUvarint(b, tag)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func readVarints(v []byte, n int) ([]int64, error) {
+	r := bytes.NewReader(v)
+	ints := make([]int64, n)
+	for i := range ints {
+		x, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, err
+		}
+		ints[i] = x
+	}
+	return ints, nil
+}
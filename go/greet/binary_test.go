@@ -0,0 +1,107 @@
+package greet
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	greeters := map[string]*SimpleGreeter{
+		"zero":      {},
+		"name only": {Name: "Ada"},
+		"all fields": {
+			Name:          "Ada",
+			Locale:        "fr",
+			Salutation:    "Dr.",
+			Suffix:        "PhD",
+			Template:      "{{.Greeting}}, {{.Name}}!",
+			RandomPhrases: []string{"Hi", "Hey", "Yo"},
+			Conjunction:   "und",
+			MaxNameLength: 12,
+			Format:        FormatHTML,
+			TimeConfig:    &TimeGreeterConfig{MorningStart: 4, AfternoonStart: 11, EveningStart: 19},
+		},
+	}
+	encodings := []struct {
+		name      string
+		marshal   func(SimpleGreeter) ([]byte, error)
+		unmarshal func(*SimpleGreeter, []byte) error
+	}{
+		{"gob", SimpleGreeter.MarshalBinary, (*SimpleGreeter).UnmarshalBinary},
+		{"proto", SimpleGreeter.MarshalProto, (*SimpleGreeter).UnmarshalProto},
+	}
+	for _, enc := range encodings {
+		for name, g := range greeters {
+			data, err := enc.marshal(*g)
+			if err != nil {
+				t.Fatalf("%s: marshal %s: %v", enc.name, name, err)
+			}
+			var got SimpleGreeter
+			if err := enc.unmarshal(&got, data); err != nil {
+				t.Fatalf("%s: unmarshal %s: %v", enc.name, name, err)
+			}
+			if !got.Equal(g) {
+				t.Errorf("%s: %s round-tripped to %+v, want %+v", enc.name, name, got, *g)
+			}
+		}
+	}
+}
+
+func TestUnmarshalProtoErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"empty", nil, "unexpected EOF"},
+		{"bad version", []byte{2}, "unsupported version 2"},
+		{"length past end", []byte{protoVersion, protoName, 5, 'A', 'd', 'a'}, "field 1: unexpected EOF"},
+		{"truncated tag", []byte{protoVersion, 0x80}, "tag: unexpected EOF"},
+		{"truncated length", []byte{protoVersion, protoName, 0x80}, "length: unexpected EOF"},
+		{"truncated value", []byte{protoVersion, protoMaxNameLength, 1, 0x80}, "max name length: unexpected EOF"},
+		{"short time config", appendField([]byte{protoVersion}, protoTimeConfig, []byte{2, 4}), "time config: EOF"},
+	}
+	for _, tt := range tests {
+		var g SimpleGreeter
+		err := g.UnmarshalProto(tt.data)
+		if err == nil || !strings.HasPrefix(err.Error(), "greet: proto: ") || !strings.HasSuffix(err.Error(), tt.want) {
+			t.Errorf("%s: UnmarshalProto(%v) = %v, want error ending in %q", tt.name, tt.data, err, tt.want)
+		}
+	}
+
+	var g SimpleGreeter
+	if err := g.UnmarshalProto(nil); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("UnmarshalProto(nil) = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestUnmarshalProtoSkipsUnknownTags(t *testing.T) {
+	data, err := SimpleGreeter{Name: "Ada", Locale: "de"}.MarshalProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Insert an unknown field between the known ones, as a newer encoder
+	// might, and append a second one with a multi-byte tag.
+	unknown := appendField(nil, 99, []byte("future"))
+	at := len(appendField([]byte{protoVersion}, protoName, []byte("Ada")))
+	data = append(data[:at:at], append(unknown, data[at:]...)...)
+	data = appendField(data, 1<<20, binary.AppendVarint(nil, -7))
+
+	var g SimpleGreeter
+	if err := g.UnmarshalProto(data); err != nil {
+		t.Fatalf("UnmarshalProto: %v", err)
+	}
+	if want := (&SimpleGreeter{Name: "Ada", Locale: "de"}); !g.Equal(want) {
+		t.Errorf("UnmarshalProto = %+v, want %+v", g, *want)
+	}
+}
+
+func TestUnmarshalBinaryError(t *testing.T) {
+	var g SimpleGreeter
+	if err := g.UnmarshalBinary([]byte("not gob")); err == nil {
+		t.Error("UnmarshalBinary(garbage) = nil, want error")
+	}
+}
@@ -0,0 +1,68 @@
+package greet
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// GreeterChain joins the greetings of several greeters, one per line. Its
+// greeters can be changed while it is in use.
+type GreeterChain struct {
+	mu       sync.RWMutex
+	greeters []Greeter
+}
+
+var _ GreeterWithError = (*GreeterChain)(nil)
+
+// NewGreeterChain returns a chain of greeters, greeted in order.
+func NewGreeterChain(greeters ...Greeter) *GreeterChain {
+	return &GreeterChain{greeters: slices.Clone(greeters)}
+}
+
+// Append adds g to the end of the chain.
+func (c *GreeterChain) Append(g Greeter) {
+	c.mu.Lock()
+	c.greeters = append(c.greeters, g)
+	c.mu.Unlock()
+}
+
+// Prepend adds g to the start of the chain.
+func (c *GreeterChain) Prepend(g Greeter) {
+	c.mu.Lock()
+	c.greeters = slices.Insert(c.greeters, 0, g)
+	c.mu.Unlock()
+}
+
+func (c *GreeterChain) snapshot() []Greeter {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.greeters)
+}
+
+// Greet joins the Greet results of the chain with newlines.
+func (c *GreeterChain) Greet() string {
+	greeters := c.snapshot()
+	lines := make([]string, len(greeters))
+	for i, g := range greeters {
+		lines[i] = g.Greet()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GreetContext greets name with each greeter in turn and joins the results
+// with newlines. It stops at the first GreeterWithError that fails,
+// returning the lines produced so far along with the error.
+func (c *GreeterChain) GreetContext(ctx context.Context, name string) (string, error) {
+	greeters := c.snapshot()
+	lines := make([]string, 0, len(greeters))
+	for _, g := range greeters {
+		s, err := GreetName(ctx, g, name)
+		if err != nil {
+			return strings.Join(lines, "\n"), err
+		}
+		lines = append(lines, s)
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,58 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGreeterChainGreet(t *testing.T) {
+	c := NewGreeterChain(SimpleGreeter{Name: "Ada"}, plainGreeter("Welcome"))
+	c.Append(SimpleGreeter{Name: "Ada", Locale: "fr"})
+	c.Prepend(plainGreeter("---"))
+	if got, want := c.Greet(), "---\nHello Ada\nWelcome\nBonjour Ada"; got != want {
+		t.Errorf("Greet() = %q, want %q", got, want)
+	}
+	if got := NewGreeterChain().Greet(); got != "" {
+		t.Errorf("empty chain Greet() = %q, want empty", got)
+	}
+}
+
+func TestGreeterChainGreetContext(t *testing.T) {
+	errBoom := errors.New("boom")
+	calls := 0
+	after := contextGreeter(func(ctx context.Context, name string) (string, error) {
+		calls++
+		return "unreachable", nil
+	})
+	c := NewGreeterChain(
+		SimpleGreeter{},
+		plainGreeter("Welcome"),
+		contextGreeter(func(ctx context.Context, name string) (string, error) { return "", errBoom }),
+		after,
+	)
+	got, err := c.GreetContext(context.Background(), "Ada")
+	if !errors.Is(err, errBoom) {
+		t.Errorf("GreetContext error = %v, want %v", err, errBoom)
+	}
+	if want := "Hello Ada\nWelcome"; got != want {
+		t.Errorf("GreetContext partial lines = %q, want %q", got, want)
+	}
+	if calls != 0 {
+		t.Error("GreetContext kept going after the first failure")
+	}
+
+	c = NewGreeterChain(SimpleGreeter{Locale: "de"}, SimpleGreeter{Locale: "es"})
+	if got, err := c.GreetContext(context.Background(), "Ada"); got != "Hallo Ada\nHola Ada" || err != nil {
+		t.Errorf("GreetContext = %q, %v; want every line", got, err)
+	}
+}
+
+func TestNewGreeterChainCopiesGreeters(t *testing.T) {
+	greeters := []Greeter{plainGreeter("a"), plainGreeter("b")}
+	c := NewGreeterChain(greeters...)
+	greeters[0] = plainGreeter("changed")
+	if got := c.Greet(); got != "a\nb" {
+		t.Errorf("Greet() after changing the argument slice = %q, want %q", got, "a\nb")
+	}
+}
@@ -0,0 +1,122 @@
+package greet
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// greeterConfig is the serialized form of a SimpleGreeter.
+type greeterConfig struct {
+	Name          string             `json:"name" yaml:"name"`
+	Locale        string             `json:"locale,omitempty" yaml:"locale,omitempty"`
+	Salutation    string             `json:"salutation,omitempty" yaml:"salutation,omitempty"`
+	Suffix        string             `json:"suffix,omitempty" yaml:"suffix,omitempty"`
+	Template      string             `json:"template,omitempty" yaml:"template,omitempty"`
+	RandomPhrases []string           `json:"random_phrases,omitempty" yaml:"random_phrases,omitempty"`
+	Conjunction   string             `json:"conjunction,omitempty" yaml:"conjunction,omitempty"`
+	MaxNameLength int                `json:"max_name_length,omitempty" yaml:"max_name_length,omitempty"`
+	Format        Format             `json:"format,omitempty" yaml:"format,omitempty"`
+	TimeConfig    *TimeGreeterConfig `json:"time_config,omitempty" yaml:"time_config,omitempty"`
+}
+
+func (g SimpleGreeter) config() greeterConfig {
+	return greeterConfig{
+		Name:          g.Name,
+		Locale:        g.Locale,
+		Salutation:    g.Salutation,
+		Suffix:        g.Suffix,
+		Template:      g.Template,
+		RandomPhrases: g.RandomPhrases,
+		Conjunction:   g.Conjunction,
+		MaxNameLength: g.MaxNameLength,
+		Format:        g.Format,
+		TimeConfig:    g.TimeConfig,
+	}
+}
+
+func (g *SimpleGreeter) setConfig(c greeterConfig) {
+	g.Name = c.Name
+	g.Locale = c.Locale
+	g.Salutation = c.Salutation
+	g.Suffix = c.Suffix
+	g.Template = c.Template
+	g.RandomPhrases = c.RandomPhrases
+	g.Conjunction = c.Conjunction
+	g.MaxNameLength = c.MaxNameLength
+	g.Format = c.Format
+	g.TimeConfig = c.TimeConfig
+}
+
+// MarshalJSON implements json.Marshaler using snake_case keys.
+func (g SimpleGreeter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.config())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Unknown keys are ignored.
+func (g *SimpleGreeter) UnmarshalJSON(data []byte) error {
+	var c greeterConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	g.setConfig(c)
+	return nil
+}
+
+// MarshalYAML implements the gopkg.in/yaml.v3 Marshaler interface with the
+// same keys as MarshalJSON.
+func (g SimpleGreeter) MarshalYAML() (any, error) {
+	return g.config(), nil
+}
+
+// UnmarshalYAML implements the function-based unmarshaler interface
+// accepted by gopkg.in/yaml.v3.
+func (g *SimpleGreeter) UnmarshalYAML(unmarshal func(any) error) error {
+	var c greeterConfig
+	if err := unmarshal(&c); err != nil {
+		return err
+	}
+	g.setConfig(c)
+	return nil
+}
+
+// Clone returns a copy of g with its configuration deep-copied, so changing
+// the clone's RandomPhrases or TimeConfig leaves g alone. Metrics, logger,
+// rate limiter and event bus are shared, so a clone draws from the same
+// rate limit as g.
+func (g *SimpleGreeter) Clone() *SimpleGreeter {
+	if g == nil {
+		return nil
+	}
+	c := *g
+	c.RandomPhrases = slices.Clone(g.RandomPhrases)
+	c.annotations = slices.Clone(g.annotations)
+	if g.TimeConfig != nil {
+		tc := *g.TimeConfig
+		c.TimeConfig = &tc
+	}
+	return &c
+}
+
+// Equal reports whether g and other have the same configuration.
+func (g *SimpleGreeter) Equal(other *SimpleGreeter) bool {
+	if g == nil || other == nil {
+		return g == other
+	}
+	return g.Name == other.Name &&
+		g.Locale == other.Locale &&
+		g.Salutation == other.Salutation &&
+		g.Suffix == other.Suffix &&
+		g.Template == other.Template &&
+		slices.Equal(g.RandomPhrases, other.RandomPhrases) &&
+		g.Conjunction == other.Conjunction &&
+		g.MaxNameLength == other.MaxNameLength &&
+		g.Format == other.Format &&
+		equalTimeConfig(g.TimeConfig, other.TimeConfig)
+}
+
+func equalTimeConfig(a, b *TimeGreeterConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
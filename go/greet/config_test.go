@@ -0,0 +1,167 @@
+package greet
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fullGreeter returns a greeter with every serialized field set.
+func fullGreeter() *SimpleGreeter {
+	return &SimpleGreeter{
+		Name:          "Ada",
+		Locale:        "fr",
+		Salutation:    "Dr.",
+		Suffix:        "PhD",
+		Template:      "{{.Greeting}}, {{.Name}}!",
+		RandomPhrases: []string{"Hi", "Hey"},
+		Conjunction:   "et",
+		MaxNameLength: 12,
+		Format:        FormatMarkdown,
+		TimeConfig:    &TimeGreeterConfig{MorningStart: 4, AfternoonStart: 11, EveningStart: 19},
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, want := range []*SimpleGreeter{{}, {Name: "Ada"}, fullGreeter()} {
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("Marshal(%+v): %v", *want, err)
+		}
+		var got SimpleGreeter
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("JSON %s round-tripped to %+v, want %+v", data, got, *want)
+		}
+	}
+}
+
+func TestJSONKeys(t *testing.T) {
+	data, err := json.Marshal(fullGreeter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"conjunction", "format", "locale", "max_name_length", "name",
+		"random_phrases", "salutation", "suffix", "template", "time_config",
+	}
+	if got := slices.Sorted(maps.Keys(m)); !slices.Equal(got, want) {
+		t.Errorf("JSON keys = %q, want %q", got, want)
+	}
+	if m["format"] != "markdown" {
+		t.Errorf("format = %v, want markdown", m["format"])
+	}
+
+	data, err = json.Marshal(&SimpleGreeter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != `{"name":""}` {
+		t.Errorf("zero greeter = %s, want only the name key", got)
+	}
+}
+
+func TestUnmarshalJSONIgnoresUnknownKeys(t *testing.T) {
+	var g SimpleGreeter
+	err := json.Unmarshal([]byte(`{"name":"Ada","locale":"de","colour":"blue","nested":{"a":1}}`), &g)
+	if err != nil {
+		t.Fatalf("Unmarshal with unknown keys: %v", err)
+	}
+	if want := (&SimpleGreeter{Name: "Ada", Locale: "de"}); !g.Equal(want) {
+		t.Errorf("Unmarshal = %+v, want %+v", g, *want)
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	for _, want := range []*SimpleGreeter{{}, {Name: "Ada"}, fullGreeter()} {
+		data, err := yaml.Marshal(want)
+		if err != nil {
+			t.Fatalf("Marshal(%+v): %v", *want, err)
+		}
+		var got SimpleGreeter
+		if err := yaml.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%q): %v", data, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("YAML %q round-tripped to %+v, want %+v", data, got, *want)
+		}
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	const doc = `
+name: Ada
+locale: de
+random_phrases: [Hi, Hey]
+max_name_length: 12
+format: html
+time_config:
+  morning_start: 4
+  afternoon_start: 11
+  evening_start: 19
+unknown: ignored
+`
+	var g SimpleGreeter
+	if err := yaml.Unmarshal([]byte(doc), &g); err != nil {
+		t.Fatal(err)
+	}
+	want := &SimpleGreeter{
+		Name:          "Ada",
+		Locale:        "de",
+		RandomPhrases: []string{"Hi", "Hey"},
+		MaxNameLength: 12,
+		Format:        FormatHTML,
+		TimeConfig:    &TimeGreeterConfig{MorningStart: 4, AfternoonStart: 11, EveningStart: 19},
+	}
+	if !g.Equal(want) {
+		t.Errorf("Unmarshal = %+v, want %+v", g, *want)
+	}
+}
+
+func TestClone(t *testing.T) {
+	orig := fullGreeter()
+	for _, opt := range []Option{WithRateLimit(100), WithAnnotations(AnnotationLocale)} {
+		if err := opt(orig); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := orig.Clone()
+	if c == orig || !c.Equal(orig) {
+		t.Fatalf("Clone() = %+v, want an equal copy of %+v", *c, *orig)
+	}
+	if c.limiter != orig.limiter {
+		t.Error("clone has its own rate limiter, want it shared")
+	}
+
+	c.RandomPhrases[0] = "Yo"
+	c.RandomPhrases = append(c.RandomPhrases, "Hiya")
+	c.TimeConfig.MorningStart = 7
+	c.annotations[0] = AnnotationTraceID
+	c.Name = "Bob"
+	if orig.RandomPhrases[0] != "Hi" || len(orig.RandomPhrases) != 2 {
+		t.Errorf("mutating the clone changed RandomPhrases to %q", orig.RandomPhrases)
+	}
+	if orig.TimeConfig.MorningStart != 4 {
+		t.Errorf("mutating the clone changed TimeConfig to %+v", *orig.TimeConfig)
+	}
+	if orig.annotations[0] != AnnotationLocale {
+		t.Errorf("mutating the clone changed annotations to %q", orig.annotations)
+	}
+	if c.Equal(orig) {
+		t.Error("mutated clone is still Equal to the original")
+	}
+
+	if (*SimpleGreeter)(nil).Clone() != nil {
+		t.Error("nil Clone() != nil")
+	}
+}
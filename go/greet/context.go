@@ -0,0 +1,96 @@
+package greet
+
+import "context"
+
+// contextKey is the type of context keys defined by this package, so they
+// cannot collide with keys defined elsewhere.
+type contextKey int
+
+const (
+	localeKey contextKey = iota
+	userIDKey
+	traceIDKey
+)
+
+// ContextWithLocale returns a copy of ctx carrying a request-scoped locale
+// tag, used by GreetContext when the greeter has no Locale of its own.
+func ContextWithLocale(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, localeKey, tag)
+}
+
+// LocaleFromContext returns the locale tag stored in ctx, if any.
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	tag, ok := ctx.Value(localeKey).(string)
+	return tag, ok
+}
+
+// ContextWithUserID returns a copy of ctx carrying the ID of the user the
+// greeting is generated for.
+func ContextWithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDKey, id)
+}
+
+// UserIDFromContext returns the user ID stored in ctx, if any.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey).(string)
+	return id, ok
+}
+
+// ContextWithTraceID returns a copy of ctx carrying the ID of the trace the
+// greeting belongs to.
+func ContextWithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey, id)
+}
+
+// TraceIDFromContext returns the trace ID stored in ctx, if any.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(traceIDKey).(string)
+	return id, ok
+}
+
+// GreeterWithError is implemented by greeters that can greet an arbitrary
+// name and report failures.
+type GreeterWithError interface {
+	Greeter
+	GreetContext(ctx context.Context, name string) (string, error)
+}
+
+var _ GreeterWithError = SimpleGreeter{}
+
+// GreetName greets name with g. Greeters that do not implement
+// GreeterWithError cannot address a name, so their Greet result is used.
+func GreetName(ctx context.Context, g Greeter, name string) (string, error) {
+	if ge, ok := g.(GreeterWithError); ok {
+		return ge.GreetContext(ctx, name)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return g.Greet(), nil
+}
+
+// GreetContext greets name, returning ctx.Err() if ctx is already done.
+func GreetContext(ctx context.Context, name string) (string, error) {
+	return SimpleGreeter{}.GreetContext(ctx, name)
+}
+
+// GreetContext greets name with g's settings in place of g.Name. A locale
+// stored in ctx applies when g.Locale is empty. Unlike Greet, a failing
+// g.Template is reported as an error.
+func (g SimpleGreeter) GreetContext(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return g.forContext(ctx, name).greet(ctx)
+}
+
+// forContext returns g set up to greet name under ctx.
+func (g SimpleGreeter) forContext(ctx context.Context, name string) SimpleGreeter {
+	if g.Locale == "" {
+		if tag, ok := LocaleFromContext(ctx); ok {
+			g.Locale = tag
+		}
+	}
+	g.Name = name
+	return g
+}
@@ -0,0 +1,102 @@
+package greet
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrMissingNameColumn is returned by GreetCSV when the header row has no
+// "name" column.
+var ErrMissingNameColumn = errors.New("greet: csv header has no name column")
+
+// GreetCSV reads RFC 4180 CSV from r and writes one greeting per row to w
+// as a single "greeting" column. The header row must contain a "name"
+// column; optional "locale" and "salutation" columns override g's settings
+// for that row. Rows with an empty name are skipped and counted. Malformed
+// input is reported as the *csv.ParseError returned by encoding/csv.
+func GreetCSV(r io.Reader, w io.Writer, g Greeter) (skipped int, err error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return 0, ErrMissingNameColumn
+	}
+	if err != nil {
+		return 0, err
+	}
+	cols := map[string]int{"name": -1, "locale": -1, "salutation": -1}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if j, ok := cols[h]; ok && j < 0 {
+			cols[h] = i
+		}
+	}
+	if cols["name"] < 0 {
+		return 0, ErrMissingNameColumn
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"greeting"}); err != nil {
+		return 0, err
+	}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return skipped, err
+		}
+		field := func(col string) string {
+			if i := cols[col]; i >= 0 && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		name := field("name")
+		if name == "" {
+			skipped++
+			continue
+		}
+		line, _ := cr.FieldPos(0)
+		s, err := greetRow(g, name, field("locale"), field("salutation"))
+		if err != nil {
+			return skipped, fmt.Errorf("greet: csv line %d: %w", line, err)
+		}
+		if err := cw.Write([]string{s}); err != nil {
+			return skipped, err
+		}
+	}
+	cw.Flush()
+	return skipped, cw.Error()
+}
+
+// greetRow greets name with g, overriding its locale and salutation when
+// they are non-empty. Greeters other than SimpleGreeter only receive the
+// locale, through the context.
+func greetRow(g Greeter, name, locale, salutation string) (string, error) {
+	var sg SimpleGreeter
+	switch v := g.(type) {
+	case SimpleGreeter:
+		sg = v
+	case *SimpleGreeter:
+		sg = *v
+	default:
+		ctx := context.Background()
+		if locale != "" {
+			ctx = ContextWithLocale(ctx, locale)
+		}
+		return GreetName(ctx, g, name)
+	}
+	if locale != "" {
+		sg.Locale = locale
+	}
+	if salutation != "" {
+		sg.Salutation = salutation
+	}
+	return sg.GreetContext(context.Background(), name)
+}
@@ -0,0 +1,133 @@
+package greet
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// contextGreeter is a GreeterWithError backed by a function of the context
+// and name, for tests that need to see what a greeter was called with.
+type contextGreeter func(ctx context.Context, name string) (string, error)
+
+func (f contextGreeter) Greet() string {
+	s, _ := f(context.Background(), "")
+	return s
+}
+
+func (f contextGreeter) GreetContext(ctx context.Context, name string) (string, error) {
+	return f(ctx, name)
+}
+
+func TestGreetCSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		g           Greeter
+		want        string
+		wantSkipped int
+	}{
+		{
+			name: "name only",
+			in:   "name\nAda\nBob\n",
+			g:    SimpleGreeter{},
+			want: "greeting\nHello Ada\nHello Bob\n",
+		},
+		{
+			name: "per-row overrides",
+			in:   "Salutation,NAME,locale\nDr.,Ada,fr\n,Bob,\nMs., Eve ,de\n",
+			g:    &SimpleGreeter{Locale: "es", Salutation: "Sr."},
+			want: "greeting\nBonjour Dr. Ada\nHola Sr. Bob\nHallo Ms. Eve\n",
+		},
+		{
+			name:        "empty names skipped",
+			in:          "id,name\n1,Ada\n2,\n3,  \n4\n5,Bob\n",
+			g:           SimpleGreeter{},
+			want:        "greeting\nHello Ada\nHello Bob\n",
+			wantSkipped: 3,
+		},
+		{
+			name: "quoted fields",
+			in:   "name\n\"Smith, Ada\"\n",
+			g:    SimpleGreeter{},
+			want: "greeting\n\"Hello Smith, Ada\"\n",
+		},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		skipped, err := GreetCSV(strings.NewReader(tt.in), &out, tt.g)
+		if err != nil {
+			t.Errorf("%s: GreetCSV: %v", tt.name, err)
+			continue
+		}
+		if got := out.String(); got != tt.want {
+			t.Errorf("%s: output = %q, want %q", tt.name, got, tt.want)
+		}
+		if skipped != tt.wantSkipped {
+			t.Errorf("%s: skipped = %d, want %d", tt.name, skipped, tt.wantSkipped)
+		}
+	}
+}
+
+func TestGreetCSVMissingNameColumn(t *testing.T) {
+	for _, in := range []string{"", "id,locale\n1,fr\n"} {
+		var out strings.Builder
+		if _, err := GreetCSV(strings.NewReader(in), &out, SimpleGreeter{}); err != ErrMissingNameColumn {
+			t.Errorf("GreetCSV(%q) = %v, want ErrMissingNameColumn", in, err)
+		}
+		if out.Len() != 0 {
+			t.Errorf("GreetCSV(%q) wrote %q, want nothing", in, out.String())
+		}
+	}
+}
+
+func TestGreetCSVParseError(t *testing.T) {
+	for _, in := range []string{
+		"name\n\"Ada\n",      // unterminated quote
+		"name\nA\"da\n",      // bare quote
+		"na\"me\nAda\n",      // bare quote in the header
+		"name\nAda\nB\"ob\n", // error after a good row
+	} {
+		_, err := GreetCSV(strings.NewReader(in), &strings.Builder{}, SimpleGreeter{})
+		var pe *csv.ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("GreetCSV(%q) = %v, want *csv.ParseError", in, err)
+			continue
+		}
+		if _, ok := err.(*csv.ParseError); !ok {
+			t.Errorf("GreetCSV(%q) = %T, want an unwrapped *csv.ParseError", in, err)
+		}
+	}
+}
+
+func TestGreetCSVContextLocale(t *testing.T) {
+	var locales []string
+	g := contextGreeter(func(ctx context.Context, name string) (string, error) {
+		tag, _ := LocaleFromContext(ctx)
+		locales = append(locales, tag)
+		return tag + ":" + name, nil
+	})
+	var out strings.Builder
+	if _, err := GreetCSV(strings.NewReader("name,locale,salutation\nAda,fr,Dr.\nBob,,\n"), &out, g); err != nil {
+		t.Fatal(err)
+	}
+	if want := "greeting\nfr:Ada\n:Bob\n"; out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+	if len(locales) != 2 || locales[0] != "fr" || locales[1] != "" {
+		t.Errorf("context locales = %q, want [fr \"\"]", locales)
+	}
+}
+
+func TestGreetCSVGreeterError(t *testing.T) {
+	g := &SimpleGreeter{Template: "{{.Missing}}"}
+	skipped, err := GreetCSV(strings.NewReader("name,id\n,1\nAda,2\n"), &strings.Builder{}, g)
+	if err == nil || !strings.HasPrefix(err.Error(), "greet: csv line 3: ") {
+		t.Errorf("GreetCSV with a failing template = %v, want a line 3 error", err)
+	}
+	if skipped != 1 {
+		t.Errorf("skipped = %d, want 1", skipped)
+	}
+}
@@ -0,0 +1,77 @@
+package greet
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ConfigGreeter is implemented by greeters that can describe their
+// configuration as field name to value pairs.
+type ConfigGreeter interface {
+	Greeter
+	GreeterConfig() map[string]string
+}
+
+var _ ConfigGreeter = SimpleGreeter{}
+
+// GreeterConfig returns g's configuration keyed by the JSON field names.
+func (g SimpleGreeter) GreeterConfig() map[string]string {
+	c := g.config()
+	m := map[string]string{
+		"name":            c.Name,
+		"locale":          c.Locale,
+		"salutation":      c.Salutation,
+		"suffix":          c.Suffix,
+		"template":        c.Template,
+		"random_phrases":  "",
+		"conjunction":     c.Conjunction,
+		"max_name_length": strconv.Itoa(c.MaxNameLength),
+		"format":          c.Format.String(),
+		"time_config":     "",
+	}
+	if len(c.RandomPhrases) > 0 {
+		m["random_phrases"] = fmt.Sprintf("%q", c.RandomPhrases)
+	}
+	if tc := c.TimeConfig; tc != nil {
+		m["time_config"] = fmt.Sprintf("%d/%d/%d", tc.MorningStart, tc.AfternoonStart, tc.EveningStart)
+	}
+	return m
+}
+
+// GreetDiff describes how the configuration of b differs from a, one
+// changed field per line in the form
+//
+//	locale: "en" -> "fr"
+//
+// Fields are sorted by name and equal fields are omitted. Greeters that do
+// not implement ConfigGreeter are treated as having no fields.
+func GreetDiff(a, b Greeter) string {
+	ca, cb := configOf(a), configOf(b)
+	keys := make([]string, 0, len(ca)+len(cb))
+	for k := range ca {
+		keys = append(keys, k)
+	}
+	for k := range cb {
+		if _, ok := ca[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		if ca[k] != cb[k] {
+			fmt.Fprintf(&sb, "%s: %q -> %q\n", k, ca[k], cb[k])
+		}
+	}
+	return sb.String()
+}
+
+func configOf(g Greeter) map[string]string {
+	if cg, ok := g.(ConfigGreeter); ok {
+		return cg.GreeterConfig()
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package greet
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Event describes a greeting that was generated.
+type Event struct {
+	Name       string
+	Greeting   string
+	Locale     string
+	OccurredAt time.Time
+}
+
+// EventBus receives greeting events. Publish is called synchronously from
+// the greeting path, so implementations should not block.
+type EventBus interface {
+	Publish(e Event)
+}
+
+// WithEventBus publishes an Event to bus after every successful greeting.
+func WithEventBus(bus EventBus) Option {
+	return func(g *SimpleGreeter) error {
+		g.bus = bus
+		return nil
+	}
+}
+
+// ChannelEventBus sends events to a channel without blocking, dropping
+// events when the channel is full.
+type ChannelEventBus struct {
+	ch      chan<- Event
+	dropped atomic.Uint64
+}
+
+// ChannelBus returns an EventBus that sends events to ch.
+func ChannelBus(ch chan<- Event) *ChannelEventBus {
+	return &ChannelEventBus{ch: ch}
+}
+
+// Publish sends e to the channel, or counts it as dropped if the channel
+// is full.
+func (b *ChannelEventBus) Publish(e Event) {
+	select {
+	case b.ch <- e:
+	default:
+		b.dropped.Add(1)
+	}
+}
+
+// DroppedEvents returns the number of events dropped so far.
+func (b *ChannelEventBus) DroppedEvents() uint64 {
+	return b.dropped.Load()
+}
+
+type multicastBus []EventBus
+
+// MulticastBus returns an EventBus that publishes every event to each of
+// buses in order.
+func MulticastBus(buses ...EventBus) EventBus {
+	return multicastBus(buses)
+}
+
+func (m multicastBus) Publish(e Event) {
+	for _, b := range m {
+		b.Publish(e)
+	}
+}
@@ -0,0 +1,74 @@
+package greet
+
+import (
+	"testing"
+	"time"
+)
+
+// recordingBus remembers every event published to it.
+type recordingBus []Event
+
+func (b *recordingBus) Publish(e Event) { *b = append(*b, e) }
+
+func TestWithEventBus(t *testing.T) {
+	var bus recordingBus
+	g, err := NewGreeter("Ada", WithLocale("de"), WithEventBus(&bus))
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := time.Now()
+	g.Greet()
+	g.Template = "{{.Missing}}"
+	g.Greet() // failures are not published
+
+	if len(bus) != 1 {
+		t.Fatalf("%d events published, want 1", len(bus))
+	}
+	e := bus[0]
+	if e.Name != "Ada" || e.Greeting != "Hallo Ada" || e.Locale != "de" {
+		t.Errorf("event = %+v, want Ada, Hallo Ada, de", e)
+	}
+	if e.OccurredAt.Before(before) || e.OccurredAt.After(time.Now()) {
+		t.Errorf("OccurredAt = %v, want during the greeting", e.OccurredAt)
+	}
+}
+
+func TestChannelBusDropsWhenFull(t *testing.T) {
+	ch := make(chan Event, 2)
+	bus := ChannelBus(ch)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		bus.Publish(Event{Name: name}) // must not block
+	}
+	if got := bus.DroppedEvents(); got != 2 {
+		t.Errorf("DroppedEvents() = %d, want 2", got)
+	}
+	if a, b := <-ch, <-ch; a.Name != "a" || b.Name != "b" {
+		t.Errorf("delivered %q, %q; want the first two events", a.Name, b.Name)
+	}
+	bus.Publish(Event{Name: "e"})
+	if got := bus.DroppedEvents(); got != 2 || (<-ch).Name != "e" {
+		t.Errorf("after draining, DroppedEvents() = %d, want the event delivered", got)
+	}
+}
+
+func TestMulticastBus(t *testing.T) {
+	var first, second recordingBus
+	ch := make(chan Event, 1)
+	full := ChannelBus(make(chan Event))
+	bus := MulticastBus(&first, full, ChannelBus(ch), &second)
+	bus.Publish(Event{Name: "Ada"})
+	bus.Publish(Event{Name: "Bob"})
+
+	for i, b := range []recordingBus{first, second} {
+		if len(b) != 2 || b[0].Name != "Ada" || b[1].Name != "Bob" {
+			t.Errorf("bus %d got %+v, want both events in order", i, b)
+		}
+	}
+	if e := <-ch; e.Name != "Ada" {
+		t.Errorf("channel bus got %q, want Ada", e.Name)
+	}
+	if got := full.DroppedEvents(); got != 2 {
+		t.Errorf("full bus dropped %d events, want 2", got)
+	}
+	MulticastBus().Publish(Event{}) // no buses is fine
+}
@@ -0,0 +1,21 @@
+package greet_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/greet"
+)
+
+func ExampleGreeterFunc() {
+	hi := greet.GreeterFunc(func(name string) string { return "Hi " + name })
+
+	s, _ := hi.GreetContext(context.Background(), "Ann")
+	fmt.Println(s)
+
+	var g greet.Greeter = hi.Bind("Bob")
+	fmt.Println(g.Greet())
+	// Output:
+	// Hi Ann
+	// Hi Bob
+}
@@ -0,0 +1,78 @@
+package greet
+
+import (
+	"fmt"
+	"html"
+)
+
+// Format selects how the name is marked up in a greeting. The zero value
+// is FormatPlain.
+type Format int
+
+const (
+	// FormatPlain leaves the name as is.
+	FormatPlain Format = iota
+	// FormatMarkdown bolds the name with **.
+	FormatMarkdown
+	// FormatHTML escapes the name and wraps it in a <span>.
+	FormatHTML
+	// FormatANSI bolds the name with terminal escape codes.
+	FormatANSI
+)
+
+var formatNames = [...]string{
+	FormatPlain:    "plain",
+	FormatMarkdown: "markdown",
+	FormatHTML:     "html",
+	FormatANSI:     "ansi",
+}
+
+// String returns the lower-case name of f, such as "html".
+func (f Format) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+	return formatNames[f]
+}
+
+// ParseFormat returns the Format named s, as returned by String.
+func ParseFormat(s string) (Format, error) {
+	for i, name := range formatNames {
+		if s == name {
+			return Format(i), nil
+		}
+	}
+	return FormatPlain, fmt.Errorf("greet: unknown format %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f Format) MarshalText() ([]byte, error) {
+	if f < 0 || int(f) >= len(formatNames) {
+		return nil, fmt.Errorf("greet: unknown format %d", int(f))
+	}
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Format) UnmarshalText(text []byte) error {
+	v, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// apply marks up name according to f.
+func (f Format) apply(name string) string {
+	switch f {
+	case FormatMarkdown:
+		return "**" + name + "**"
+	case FormatHTML:
+		return `<span class="name">` + html.EscapeString(name) + "</span>"
+	case FormatANSI:
+		return "\x1b[1m" + name + "\x1b[0m"
+	default:
+		return name
+	}
+}
@@ -0,0 +1,83 @@
+package greet
+
+import "testing"
+
+func TestFormatGreet(t *testing.T) {
+	tests := []struct {
+		g    SimpleGreeter
+		want string
+	}{
+		{SimpleGreeter{Name: "Ada"}, "Hello Ada"},
+		{SimpleGreeter{Name: "Ada", Format: FormatMarkdown}, "Hello **Ada**"},
+		{SimpleGreeter{Name: "Ada", Format: FormatANSI}, "Hello \x1b[1mAda\x1b[0m"},
+		{SimpleGreeter{Name: "Ada", Salutation: "Dr.", Format: FormatHTML}, `Hello <span class="name">Dr. Ada</span>`},
+		{
+			SimpleGreeter{Name: `<script>alert("x")</script>`, Format: FormatHTML},
+			`Hello <span class="name">&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</span>`,
+		},
+		{SimpleGreeter{Name: "Tom & Jerry", Locale: "fr", Format: FormatHTML}, `Bonjour <span class="name">Tom &amp; Jerry</span>`},
+	}
+	for _, tt := range tests {
+		if got := tt.g.Greet(); got != tt.want {
+			t.Errorf("%v Greet(%q) = %q, want %q", tt.g.Format, tt.g.Name, got, tt.want)
+		}
+	}
+}
+
+func TestFormatGreetMultiple(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatPlain, "Hello A, B, and <C>"},
+		{FormatMarkdown, "Hello **A**, **B**, and **<C>**"},
+		{FormatHTML, `Hello <span class="name">A</span>, <span class="name">B</span>, and <span class="name">&lt;C&gt;</span>`},
+	}
+	for _, tt := range tests {
+		g := SimpleGreeter{Format: tt.format}
+		if got := g.GreetMultiple("A", "B", "<C>"); got != tt.want {
+			t.Errorf("%v GreetMultiple = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range []Format{FormatPlain, FormatMarkdown, FormatHTML, FormatANSI} {
+		got, err := ParseFormat(f.String())
+		if got != f || err != nil {
+			t.Errorf("ParseFormat(%q) = %v, %v; want %v", f.String(), got, err, f)
+		}
+		text, err := f.MarshalText()
+		if err != nil {
+			t.Errorf("%v.MarshalText: %v", f, err)
+		}
+		var back Format
+		if err := back.UnmarshalText(text); err != nil || back != f {
+			t.Errorf("UnmarshalText(%q) = %v, %v; want %v", text, back, err, f)
+		}
+	}
+	if got, want := FormatHTML.String(), "html"; got != want {
+		t.Errorf("FormatHTML.String() = %q, want %q", got, want)
+	}
+	if _, err := ParseFormat("HTML"); err == nil {
+		t.Error(`ParseFormat("HTML") succeeded, want names to be case-sensitive`)
+	}
+	var f Format = FormatMarkdown
+	if err := f.UnmarshalText([]byte("xml")); err == nil || f != FormatMarkdown {
+		t.Errorf("UnmarshalText(xml) = %v, left %v; want an error and no change", err, f)
+	}
+}
+
+func TestFormatOutOfRange(t *testing.T) {
+	for _, f := range []Format{-1, 4} {
+		if _, err := f.MarshalText(); err == nil {
+			t.Errorf("Format(%d).MarshalText succeeded", int(f))
+		}
+		if got := (SimpleGreeter{Name: "Ada", Format: f}).Greet(); got != "Hello Ada" {
+			t.Errorf("Format(%d) Greet = %q, want it treated as plain", int(f), got)
+		}
+	}
+	if got, want := Format(9).String(), "Format(9)"; got != want {
+		t.Errorf("Format(9).String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package greet
+
+import "context"
+
+// GreeterFunc adapts an ordinary function to GreeterWithError, which makes
+// it easy to stub greeters in tests. GreetContext passes the requested name
+// to f. Greet has no name to pass, so it calls f with ""; use Bind to fix
+// the name instead.
+type GreeterFunc func(name string) string
+
+var (
+	_ GreeterWithError = GreeterFunc(nil)
+	_ GreeterWithError = boundGreeterFunc{}
+)
+
+// Greet calls f with an empty name.
+func (f GreeterFunc) Greet() string {
+	return f("")
+}
+
+// GreetContext calls f with name unless ctx is already done.
+func (f GreeterFunc) GreetContext(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return f(name), nil
+}
+
+// Bind returns a Greeter whose Greet calls f with name.
+func (f GreeterFunc) Bind(name string) GreeterWithError {
+	return boundGreeterFunc{f: f, name: name}
+}
+
+type boundGreeterFunc struct {
+	f    GreeterFunc
+	name string
+}
+
+func (b boundGreeterFunc) Greet() string {
+	return b.f(b.name)
+}
+
+func (b boundGreeterFunc) GreetContext(ctx context.Context, name string) (string, error) {
+	return b.f.GreetContext(ctx, name)
+}
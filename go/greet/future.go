@@ -0,0 +1,60 @@
+package greet
+
+import "context"
+
+// Future is the result of a computation running in the background.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// GreetFuture is the Future returned by AsyncGreet.
+type GreetFuture = Future[string]
+
+// goFuture runs fn in a new goroutine. The future completes when fn
+// returns or ctx is done, whichever happens first.
+func goFuture[T any](ctx context.Context, fn func(context.Context) (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+	type result struct {
+		value T
+		err   error
+	}
+	go func() {
+		defer close(f.done)
+		ch := make(chan result, 1)
+		go func() {
+			v, err := fn(ctx)
+			ch <- result{v, err}
+		}()
+		select {
+		case r := <-ch:
+			f.value, f.err = r.value, r.err
+		case <-ctx.Done():
+			f.err = ctx.Err()
+		}
+	}()
+	return f
+}
+
+// Await blocks until the future completes and returns its result. If the
+// context it was started with is done first, Await returns the zero value
+// and the context's error.
+func (f *Future[T]) Await() (T, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// Done returns a channel that is closed when the future completes, so that
+// callers can select on it. Await does not block once it is closed.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// AsyncGreet greets name with g in a new goroutine and returns a future
+// for the greeting without waiting for it.
+func AsyncGreet(ctx context.Context, g Greeter, name string) *GreetFuture {
+	return goFuture(ctx, func(ctx context.Context) (string, error) {
+		return GreetName(ctx, g, name)
+	})
+}
@@ -0,0 +1,54 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAsyncGreet(t *testing.T) {
+	f := AsyncGreet(context.Background(), SimpleGreeter{Locale: "fr"}, "Ada")
+	select {
+	case <-f.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Done was not closed")
+	}
+	for range 2 {
+		if got, err := f.Await(); got != "Bonjour Ada" || err != nil {
+			t.Errorf("Await() = %q, %v; want Bonjour Ada, nil", got, err)
+		}
+	}
+
+	errBoom := errors.New("boom")
+	failing := contextGreeter(func(context.Context, string) (string, error) { return "", errBoom })
+	if _, err := AsyncGreet(context.Background(), failing, "Ada").Await(); err != errBoom {
+		t.Errorf("Await() with a failing greeter = %v, want %v", err, errBoom)
+	}
+}
+
+func TestAsyncGreetCancel(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	blocked := contextGreeter(func(context.Context, string) (string, error) {
+		<-release // ignores ctx, so only the future can give up
+		return "too late", nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	f := AsyncGreet(ctx, blocked, "Ada")
+
+	select {
+	case <-f.Done():
+		t.Fatal("Done closed before the greeting finished or ctx was cancelled")
+	case <-time.After(10 * time.Millisecond):
+	}
+	cancel()
+	select {
+	case <-f.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Done was not closed after cancel")
+	}
+	if got, err := f.Await(); got != "" || err != context.Canceled {
+		t.Errorf("Await() after cancel = %q, %v; want \"\", context.Canceled", got, err)
+	}
+}
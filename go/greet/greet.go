@@ -0,0 +1,189 @@
+// Package greet is a small greeting library. Greeters are built with
+// NewGreeter and functional options:
+//
+//	g, err := greet.NewGreeter("Curie", greet.WithSalutation("Dr."), greet.WithLocale("fr"))
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(g.Greet()) // Bonjour Dr. Curie
+//
+// # Binary format
+//
+// SimpleGreeter.MarshalProto writes a compact encoding of a greeter's
+// configuration for caches. It is a version byte, currently 1, followed by
+// fields, each written as a uvarint tag, a uvarint length and that many
+// bytes of value:
+//
+//	tag  field          value
+//	1    Name           UTF-8 string
+//	2    Locale         UTF-8 string
+//	3    Salutation     UTF-8 string
+//	4    Suffix         UTF-8 string
+//	5    Template       UTF-8 string
+//	6    RandomPhrases  UTF-8 string, one field per phrase, in order
+//	7    Conjunction    UTF-8 string
+//	8    MaxNameLength  varint
+//	9    TimeConfig     three varints: morning, afternoon and evening start
+//	10   Format         varint
+//
+// Empty fields are omitted. Decoders skip fields with unknown tags, so new
+// fields can be added under new tags without breaking existing entries;
+// tags are never reused. Changes that old decoders cannot skip safely bump
+// the version byte, and SimpleGreeter.UnmarshalProto rejects versions it
+// does not know.
+package greet
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Greeter is implemented by anything that can produce a greeting.
+type Greeter interface {
+	Greet() string
+}
+
+// SimpleGreeter greets a single name in its configured locale. Prefer
+// NewGreeter over a struct literal so the name is validated.
+type SimpleGreeter struct {
+	Name string
+
+	// Locale is an IETF language tag selecting the greeting template.
+	// Empty means DefaultLocale.
+	Locale string
+
+	// Salutation and Suffix are placed around the name when non-empty.
+	Salutation string
+	Suffix     string
+
+	// Template, when non-empty, is a text/template used instead of the
+	// locale's template. See TemplateData for the available fields.
+	Template string
+
+	// RandomPhrases, when non-empty and Template is empty, are picked from
+	// at random as in GreetRandom.
+	RandomPhrases []string
+
+	// Conjunction joins the last two names in GreetMultiple; empty means
+	// DefaultConjunction.
+	Conjunction string
+
+	// MaxNameLength is the longest name, in bytes, accepted by strict
+	// validation; zero means DefaultMaxNameLength.
+	MaxNameLength int
+
+	// Format marks up the name in the greeting; the zero value is
+	// FormatPlain.
+	Format Format
+
+	// TimeConfig overrides DefaultTimeGreeterConfig for GreetWithTime.
+	TimeConfig *TimeGreeterConfig
+
+	metrics Metrics
+	logger  *slog.Logger
+	limiter *rate.Limiter
+	bus     EventBus
+
+	annotations  []string
+	norm         NormOpts
+	scoreWeights ScoreWeights
+}
+
+var _ Greeter = SimpleGreeter{}
+
+// Greet returns the default greeting for name.
+func Greet(name string) string {
+	var g Greeter = SimpleGreeter{Name: name}
+	return g.Greet()
+}
+
+// Greet returns the greeting for g.Name from g.Template or
+// g.RandomPhrases, or from the template for g.Locale if neither is set or
+// Template fails to render.
+func (g SimpleGreeter) Greet() string {
+	s, err := g.greet(context.Background())
+	if err != nil {
+		return g.GreetLocale().Greeting
+	}
+	return s
+}
+
+// greet composes the greeting for g.Name once the rate limiter, if any,
+// allows it, recording metrics, logging and publishing events if
+// configured.
+func (g SimpleGreeter) greet(ctx context.Context) (string, error) {
+	if g.limiter != nil {
+		if err := g.limiter.Wait(ctx); err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			// Wait gives up early, with an error of its own, when the next
+			// token would arrive after ctx's deadline.
+			return "", context.DeadlineExceeded
+		}
+	}
+	if g.metrics == nil && g.logger == nil && g.bus == nil {
+		return g.compose()
+	}
+	start := time.Now()
+	s, err := g.compose()
+	d := time.Since(start)
+	if g.metrics != nil {
+		// Label with the resolved tag: g.Locale can come from callers and
+		// would otherwise be an unbounded label value.
+		_, tag, _ := lookupLocale(g.Locale)
+		g.metrics.ObserveGreet(tag, g.Name, d)
+	}
+	if g.logger != nil && err == nil {
+		g.logger.LogAttrs(ctx, slog.LevelDebug, "greeting generated",
+			slog.String("name", g.Name),
+			slog.String("locale", g.Locale),
+			slog.Int64("duration_ns", d.Nanoseconds()),
+		)
+	}
+	if g.bus != nil && err == nil {
+		g.bus.Publish(Event{Name: g.Name, Greeting: s, Locale: g.Locale, OccurredAt: start.Add(d)})
+	}
+	return s, err
+}
+
+// compose builds the greeting for g.Name from the most specific source
+// configured on g.
+func (g SimpleGreeter) compose() (string, error) {
+	switch {
+	case g.Template != "":
+		return g.GreetTemplate()
+	case len(g.RandomPhrases) > 0:
+		return GreetRandom(g.addressee(), g.RandomPhrases), nil
+	default:
+		return g.GreetLocale().Greeting, nil
+	}
+}
+
+// GreetFormal greets name with a salutation and suffix, either of which
+// may be empty, e.g. GreetFormal("Dr.", "Smith", "M.D.").
+func GreetFormal(salutation, name, suffix string) string {
+	return SimpleGreeter{Name: name, Salutation: salutation, Suffix: suffix}.Greet()
+}
+
+// addressee formats "<Salutation> <Name>, <Suffix>", omitting empty parts,
+// and marks it up according to g.Format. The name is normalized as set by
+// WithNormalization and invalid UTF-8 is replaced; every greeting renders
+// the name through here.
+func (g SimpleGreeter) addressee() string {
+	s := validUTF8(g.norm.Apply(g.Name, g.Locale))
+	if g.Salutation != "" {
+		s = strings.TrimSpace(g.Salutation + " " + s)
+	}
+	if g.Suffix != "" {
+		if s != "" {
+			s += ", "
+		}
+		s += g.Suffix
+	}
+	return g.Format.apply(s)
+}
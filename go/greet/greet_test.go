@@ -0,0 +1,49 @@
+package greet
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestWithLoggerLogsGreeting(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	g, err := NewGreeter("Ada", WithLocale("fr"), WithLogger(l))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Greet()
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log output %q is not one JSON entry: %v", buf.String(), err)
+	}
+	if entry["level"] != "DEBUG" || entry["msg"] != "greeting generated" {
+		t.Errorf("level, msg = %v, %v; want DEBUG, greeting generated", entry["level"], entry["msg"])
+	}
+	if entry["name"] != "Ada" {
+		t.Errorf("name = %v, want Ada", entry["name"])
+	}
+	if entry["locale"] != "fr" {
+		t.Errorf("locale = %v, want fr", entry["locale"])
+	}
+	if d, ok := entry["duration_ns"].(float64); !ok || d < 0 {
+		t.Errorf("duration_ns = %v, want a non-negative number", entry["duration_ns"])
+	}
+}
+
+func TestWithLoggerSkipsFailedGreetings(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	g, err := NewGreeter("Ada", WithLogger(l))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Template = "{{.Missing}}"
+	g.Greet()
+	if buf.Len() != 0 {
+		t.Errorf("failed greeting logged %q", buf.String())
+	}
+}
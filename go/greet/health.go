@@ -0,0 +1,67 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+)
+
+// HealthCheckName is the name greeted by health checks.
+const HealthCheckName = "__healthcheck__"
+
+// ErrUnhealthy is wrapped by errors from failed health checks.
+var ErrUnhealthy = errors.New("greet: health check failed")
+
+// HealthChecker is implemented by greeters that can test themselves.
+// Greeters that do not implement it are checked by greeting
+// HealthCheckName.
+type HealthChecker interface {
+	HealthCheck() error
+}
+
+var _ HealthChecker = SimpleGreeter{}
+
+// HealthCheck greets HealthCheckName with g's settings and reports an
+// error wrapping ErrUnhealthy if that fails or produces an empty or
+// invalid UTF-8 greeting. It bypasses the rate limiter, metrics, logging
+// and events.
+func (g SimpleGreeter) HealthCheck() error {
+	g.Name = HealthCheckName
+	return checkGreeting(g.compose())
+}
+
+// NewHealthHandler returns a handler for liveness and readiness probes. It
+// responds 200 if g is healthy and 503 with the error text otherwise.
+// Responses are never cached.
+func NewHealthHandler(g Greeter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if hc, ok := g.(HealthChecker); ok {
+			err = hc.HealthCheck()
+		} else {
+			err = checkGreeting(GreetName(r.Context(), g, HealthCheckName))
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		if err != nil {
+			writeGreeting(w, http.StatusServiceUnavailable, false, "error", err.Error())
+			return
+		}
+		writeGreeting(w, http.StatusOK, false, "status", "ok")
+	}
+}
+
+func checkGreeting(s string, err error) error {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err
+	case err != nil:
+		return fmt.Errorf("%w: %w", ErrUnhealthy, err)
+	case s == "":
+		return fmt.Errorf("%w: empty greeting", ErrUnhealthy)
+	case !utf8.ValidString(s):
+		return fmt.Errorf("%w: greeting is not valid UTF-8", ErrUnhealthy)
+	}
+	return nil
+}
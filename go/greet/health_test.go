@@ -0,0 +1,63 @@
+package greet
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthCheckLatency(t *testing.T) {
+	const budget = time.Millisecond
+	if deadline, ok := t.Deadline(); ok && time.Until(deadline) < 100*budget {
+		t.Skip("not enough time left before the test deadline")
+	}
+	g := SimpleGreeter{Name: "Ada"}
+	if err := g.HealthCheck(); err != nil { // warm the template cache
+		t.Fatal(err)
+	}
+	const runs = 100
+	start := time.Now()
+	for range runs {
+		if err := g.HealthCheck(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start) / runs; d > budget {
+		t.Errorf("HealthCheck took %v on average, want at most %v", d, budget)
+	}
+}
+
+func TestHealthCheckFailure(t *testing.T) {
+	err := SimpleGreeter{Template: "{{.Missing}}"}.HealthCheck()
+	if !errors.Is(err, ErrUnhealthy) {
+		t.Errorf("HealthCheck = %v, want ErrUnhealthy", err)
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		g        Greeter
+		code     int
+		contains string
+	}{
+		{"healthy", SimpleGreeter{}, http.StatusOK, "ok"},
+		{"failing template", SimpleGreeter{Template: "{{.Missing}}"}, http.StatusServiceUnavailable, "health check failed"},
+		{"empty greeting", GreeterFunc(func(string) string { return "" }), http.StatusServiceUnavailable, "empty greeting"},
+	} {
+		rec := httptest.NewRecorder()
+		NewHealthHandler(tc.g)(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		if rec.Code != tc.code {
+			t.Errorf("%s: status = %d, want %d", tc.desc, rec.Code, tc.code)
+		}
+		if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+			t.Errorf("%s: Cache-Control = %q, want no-store", tc.desc, got)
+		}
+		if body := rec.Body.String(); !strings.Contains(body, tc.contains) {
+			t.Errorf("%s: body = %q, want it to contain %q", tc.desc, body, tc.contains)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package greet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// DefaultNameParam is the query parameter GreeterHandler reads by default.
+const DefaultNameParam = "name"
+
+// GreeterHandler is an http.Handler that greets the name given in a query
+// parameter. Responses are plain text unless the request accepts
+// application/json, in which case they are {"greeting": "..."}.
+type GreeterHandler struct {
+	// Greeter supplies the locale, salutation and other settings. Its Name
+	// is ignored in favour of the requested name.
+	Greeter SimpleGreeter
+	// Param is the query parameter holding the name; empty means
+	// DefaultNameParam.
+	Param string
+}
+
+var _ http.Handler = (*GreeterHandler)(nil)
+
+// NewGreeterHandler returns a handler greeting with g's settings.
+func NewGreeterHandler(g SimpleGreeter) *GreeterHandler {
+	return &GreeterHandler{Greeter: g, Param: DefaultNameParam}
+}
+
+// ServeHTTP implements http.Handler. An empty or invalid name results in
+// 400 Bad Request, and a greeting that misses the request's deadline, such
+// as one held up by WithRateLimit, in 503 Service Unavailable. The greeting
+// is generated with the request's context.
+func (h *GreeterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	param := h.Param
+	if param == "" {
+		param = DefaultNameParam
+	}
+	asJSON := strings.Contains(r.Header.Get("Accept"), "application/json")
+
+	name := r.URL.Query().Get(param)
+	if err := validateName(name, h.Greeter.strictMaxNameLength()); err != nil {
+		writeGreeting(w, http.StatusBadRequest, asJSON, "error", err.Error())
+		return
+	}
+	greeting, err := h.Greeter.GreetContext(r.Context(), name)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, context.DeadlineExceeded) {
+			status = http.StatusServiceUnavailable
+		}
+		writeGreeting(w, status, asJSON, "error", err.Error())
+		return
+	}
+	writeGreeting(w, http.StatusOK, asJSON, "greeting", greeting)
+}
+
+func writeGreeting(w http.ResponseWriter, status int, asJSON bool, key, value string) {
+	if asJSON {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]string{key: value})
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write([]byte(value))
+}
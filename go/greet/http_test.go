@@ -0,0 +1,164 @@
+package greet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGreeterHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		h          *GreeterHandler
+		query      string
+		accept     string
+		wantStatus int
+		wantType   string
+		wantBody   string
+	}{
+		{
+			name:       "plain text",
+			h:          NewGreeterHandler(SimpleGreeter{Salutation: "Dr."}),
+			query:      "name=Ada",
+			wantStatus: http.StatusOK,
+			wantType:   "text/plain; charset=utf-8",
+			wantBody:   "Hello Dr. Ada",
+		},
+		{
+			name:       "json",
+			h:          NewGreeterHandler(SimpleGreeter{Locale: "fr"}),
+			query:      "name=Ada",
+			accept:     "text/html, application/json;q=0.9",
+			wantStatus: http.StatusOK,
+			wantType:   "application/json",
+			wantBody:   `{"greeting":"Bonjour Ada"}` + "\n",
+		},
+		{
+			name:       "empty name",
+			h:          NewGreeterHandler(SimpleGreeter{}),
+			query:      "name=",
+			wantStatus: http.StatusBadRequest,
+			wantType:   "text/plain; charset=utf-8",
+			wantBody:   ErrEmptyName.Error(),
+		},
+		{
+			name:       "missing name as json",
+			h:          NewGreeterHandler(SimpleGreeter{}),
+			accept:     "application/json",
+			wantStatus: http.StatusBadRequest,
+			wantType:   "application/json",
+			wantBody:   `{"error":"greet: empty name"}` + "\n",
+		},
+		{
+			name:       "control character",
+			h:          NewGreeterHandler(SimpleGreeter{}),
+			query:      "name=" + url.QueryEscape("Ada\x1b[31m"),
+			wantStatus: http.StatusBadRequest,
+			wantType:   "text/plain; charset=utf-8",
+			wantBody:   ErrInvalidCharacter.Error() + ": U+001B at byte 3",
+		},
+		{
+			name:       "custom param",
+			h:          &GreeterHandler{Param: "who"},
+			query:      "name=Bob&who=Ada",
+			wantStatus: http.StatusOK,
+			wantType:   "text/plain; charset=utf-8",
+			wantBody:   "Hello Ada",
+		},
+		{
+			name:       "zero handler uses DefaultNameParam",
+			h:          &GreeterHandler{},
+			query:      "name=Ada",
+			wantStatus: http.StatusOK,
+			wantType:   "text/plain; charset=utf-8",
+			wantBody:   "Hello Ada",
+		},
+		{
+			name:       "failing template",
+			h:          NewGreeterHandler(SimpleGreeter{Template: "{{.Missing}}"}),
+			query:      "name=Ada",
+			accept:     "application/json",
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "application/json",
+		},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		rec := httptest.NewRecorder()
+		tt.h.ServeHTTP(rec, req)
+
+		if rec.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.wantStatus)
+		}
+		if got := rec.Header().Get("Content-Type"); got != tt.wantType {
+			t.Errorf("%s: Content-Type = %q, want %q", tt.name, got, tt.wantType)
+		}
+		if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+			t.Errorf("%s: body = %q, want %q", tt.name, rec.Body.String(), tt.wantBody)
+		}
+	}
+}
+
+func TestGreeterHandlerTemplateError(t *testing.T) {
+	h := NewGreeterHandler(SimpleGreeter{Template: "{{.Missing}}"})
+	req := httptest.NewRequest(http.MethodGet, "/?name=Ada", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body %q: %v", rec.Body.String(), err)
+	}
+	if !strings.Contains(body["error"], "Missing") {
+		t.Errorf(`body["error"] = %q, want the template error`, body["error"])
+	}
+	if _, ok := body["greeting"]; ok {
+		t.Errorf("body = %v, want no greeting", body)
+	}
+}
+
+func TestGreeterHandlerRequestContext(t *testing.T) {
+	h := NewGreeterHandler(SimpleGreeter{})
+	req := httptest.NewRequest(http.MethodGet, "/?name=Ada", nil)
+	req = req.WithContext(ContextWithLocale(req.Context(), "de"))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if got, want := rec.Body.String(), "Hallo Ada"; got != want {
+		t.Errorf("body with a locale in the request context = %q, want %q", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?name=Ada", nil).WithContext(ctx))
+	if rec.Code != http.StatusInternalServerError || rec.Body.String() != context.Canceled.Error() {
+		t.Errorf("cancelled request = %d %q, want 500 %q", rec.Code, rec.Body.String(), context.Canceled)
+	}
+}
+
+func TestGreeterHandlerDeadline(t *testing.T) {
+	g, err := NewGreeter("Ada", WithRateLimit(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Greet() // take the only token
+	h := NewGreeterHandler(*g)
+
+	req := httptest.NewRequest(http.MethodGet, "/?name=Ada", nil)
+	ctx, cancel := context.WithTimeout(req.Context(), 10*time.Millisecond)
+	defer cancel()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req.WithContext(ctx))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
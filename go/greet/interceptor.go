@@ -0,0 +1,110 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// ErrNameNotAllowed is returned by AllowlistInterceptor for names outside
+// its allow-list.
+var ErrNameNotAllowed = errors.New("greet: name not allowed")
+
+// Interceptor inspects or changes a greeting request and its result. It
+// calls handler to continue the chain, or returns without calling it to
+// reject the request.
+type Interceptor func(ctx context.Context, name string, handler func(context.Context, string) (string, error)) (string, error)
+
+// ChainInterceptors combines interceptors into one. The first interceptor
+// is outermost: it sees the request first and the result last.
+func ChainInterceptors(interceptors ...Interceptor) Interceptor {
+	interceptors = slices.Clone(interceptors)
+	return func(ctx context.Context, name string, handler func(context.Context, string) (string, error)) (string, error) {
+		h := handler
+		for _, i := range slices.Backward(interceptors) {
+			next := h
+			h = func(ctx context.Context, name string) (string, error) {
+				return i(ctx, name, next)
+			}
+		}
+		return h(ctx, name)
+	}
+}
+
+// NewInterceptedGreeter returns a Greeter that sends every greeting of g
+// through i. Greet passes i the empty name and returns "" if i fails.
+func NewInterceptedGreeter(g Greeter, i Interceptor) Greeter {
+	return interceptedGreeter{g: g, i: i}
+}
+
+type interceptedGreeter struct {
+	g Greeter
+	i Interceptor
+}
+
+func (ig interceptedGreeter) Greet() string {
+	s, _ := ig.GreetContext(context.Background(), "")
+	return s
+}
+
+func (ig interceptedGreeter) GreetContext(ctx context.Context, name string) (string, error) {
+	return ig.i(ctx, name, ig.handle)
+}
+
+// handle greets name with the wrapped greeter, using its own Greet when
+// name is empty.
+func (ig interceptedGreeter) handle(ctx context.Context, name string) (string, error) {
+	if name == "" {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		return ig.g.Greet(), nil
+	}
+	return GreetName(ctx, ig.g, name)
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// RedactPIIInterceptor replaces email addresses in the name with
+// "[REDACTED]" before it is greeted.
+func RedactPIIInterceptor(ctx context.Context, name string, handler func(context.Context, string) (string, error)) (string, error) {
+	return handler(ctx, emailPattern.ReplaceAllString(name, "[REDACTED]"))
+}
+
+// AllowlistInterceptor rejects names not in allowed with an error wrapping
+// ErrNameNotAllowed. The empty name, used by Greet, is always allowed.
+func AllowlistInterceptor(allowed []string) Interceptor {
+	set := make(map[string]struct{}, len(allowed))
+	for _, name := range allowed {
+		set[name] = struct{}{}
+	}
+	return func(ctx context.Context, name string, handler func(context.Context, string) (string, error)) (string, error) {
+		if _, ok := set[name]; !ok && name != "" {
+			return "", fmt.Errorf("%w: %q", ErrNameNotAllowed, name)
+		}
+		return handler(ctx, name)
+	}
+}
+
+// LoggingInterceptor logs every greeting to l at Info level, or at Error
+// level if it fails.
+func LoggingInterceptor(l *slog.Logger) Interceptor {
+	return func(ctx context.Context, name string, handler func(context.Context, string) (string, error)) (string, error) {
+		start := time.Now()
+		s, err := handler(ctx, name)
+		attrs := []slog.Attr{
+			slog.String("name", name),
+			slog.Int64("duration_ns", time.Since(start).Nanoseconds()),
+		}
+		if err != nil {
+			l.LogAttrs(ctx, slog.LevelError, "greeting failed", append(attrs, slog.String("error", err.Error()))...)
+		} else {
+			l.LogAttrs(ctx, slog.LevelInfo, "greeting generated", attrs...)
+		}
+		return s, err
+	}
+}
@@ -0,0 +1,128 @@
+package greet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// recordInterceptor appends "<tag>>" on the way in and "<<tag" on the way
+// out to *trace.
+func recordInterceptor(tag string, trace *[]string) Interceptor {
+	return func(ctx context.Context, name string, handler func(context.Context, string) (string, error)) (string, error) {
+		*trace = append(*trace, tag+">")
+		s, err := handler(ctx, name+"+"+tag)
+		*trace = append(*trace, "<"+tag)
+		return s, err
+	}
+}
+
+func TestChainInterceptorsOrder(t *testing.T) {
+	var trace []string
+	chain := ChainInterceptors(recordInterceptor("a", &trace), recordInterceptor("b", &trace), recordInterceptor("c", &trace))
+	got, err := GreetName(context.Background(), NewInterceptedGreeter(SimpleGreeter{}, chain), "Ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Hello Ada+a+b+c"; got != want {
+		t.Errorf("greeting = %q, want %q", got, want)
+	}
+	if want := []string{"a>", "b>", "c>", "<c", "<b", "<a"}; !slices.Equal(trace, want) {
+		t.Errorf("trace = %q, want %q", trace, want)
+	}
+}
+
+func TestChainInterceptorsEmpty(t *testing.T) {
+	got, err := GreetName(context.Background(), NewInterceptedGreeter(SimpleGreeter{}, ChainInterceptors()), "Ada")
+	if got != "Hello Ada" || err != nil {
+		t.Errorf("empty chain = %q, %v; want Hello Ada, nil", got, err)
+	}
+}
+
+func TestInterceptedGreeterGreet(t *testing.T) {
+	var names []string
+	record := func(ctx context.Context, name string, handler func(context.Context, string) (string, error)) (string, error) {
+		names = append(names, name)
+		return handler(ctx, name)
+	}
+	g := NewInterceptedGreeter(SimpleGreeter{Name: "Ada", Locale: "fr"}, record)
+	if got, want := g.Greet(), "Bonjour Ada"; got != want {
+		t.Errorf("Greet() = %q, want %q", got, want)
+	}
+	if len(names) != 1 || names[0] != "" {
+		t.Errorf("interceptor saw %q, want the empty name", names)
+	}
+
+	reject := func(context.Context, string, func(context.Context, string) (string, error)) (string, error) {
+		return "", errors.New("rejected")
+	}
+	if got := NewInterceptedGreeter(SimpleGreeter{Name: "Ada"}, reject).Greet(); got != "" {
+		t.Errorf("Greet() through a failing interceptor = %q, want empty", got)
+	}
+}
+
+func TestRedactPIIInterceptor(t *testing.T) {
+	g := NewInterceptedGreeter(SimpleGreeter{}, RedactPIIInterceptor)
+	for name, want := range map[string]string{
+		"ada@example.com":          "Hello [REDACTED]",
+		"Ada <ada.l+x@mail.co.uk>": "Hello Ada <[REDACTED]>",
+		"a@b.io and c@d.org":       "Hello [REDACTED] and [REDACTED]",
+		"Ada":                      "Hello Ada",
+		"not@an-email":             "Hello not@an-email",
+	} {
+		if got, err := GreetName(context.Background(), g, name); got != want || err != nil {
+			t.Errorf("GreetName(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+}
+
+func TestAllowlistInterceptor(t *testing.T) {
+	g := NewInterceptedGreeter(SimpleGreeter{Name: "Eve"}, AllowlistInterceptor([]string{"Ada", "Bob"}))
+	if got, err := GreetName(context.Background(), g, "Bob"); got != "Hello Bob" || err != nil {
+		t.Errorf("allowed name = %q, %v; want Hello Bob, nil", got, err)
+	}
+	got, err := GreetName(context.Background(), g, "Mallory")
+	if !errors.Is(err, ErrNameNotAllowed) || got != "" {
+		t.Errorf("disallowed name = %q, %v; want ErrNameNotAllowed", got, err)
+	}
+	if !strings.Contains(err.Error(), `"Mallory"`) {
+		t.Errorf("error %q does not name the rejected name", err)
+	}
+	if got := g.Greet(); got != "Hello Eve" {
+		t.Errorf("Greet() = %q, want the empty name allowed", got)
+	}
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewJSONHandler(&buf, nil))
+	g := NewInterceptedGreeter(SimpleGreeter{Template: "{{if eq .Name \"bad\"}}{{.Missing}}{{end}}ok"}, LoggingInterceptor(l))
+
+	for _, tc := range []struct {
+		name, level, msg string
+	}{
+		{"Ada", "INFO", "greeting generated"},
+		{"bad", "ERROR", "greeting failed"},
+	} {
+		buf.Reset()
+		GreetName(context.Background(), g, tc.name)
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("%s: log output %q: %v", tc.name, buf.String(), err)
+		}
+		if entry["level"] != tc.level || entry["msg"] != tc.msg || entry["name"] != tc.name {
+			t.Errorf("%s: logged %v, want level %s, msg %q", tc.name, entry, tc.level, tc.msg)
+		}
+		if _, ok := entry["duration_ns"].(float64); !ok {
+			t.Errorf("%s: duration_ns = %v, want a number", tc.name, entry["duration_ns"])
+		}
+		if _, ok := entry["error"]; ok != (tc.level == "ERROR") {
+			t.Errorf("%s: error attribute = %v", tc.name, entry["error"])
+		}
+	}
+}
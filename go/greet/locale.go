@@ -0,0 +1,108 @@
+package greet
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode/utf8"
+)
+
+// DefaultLocale is used when a greeter has no locale or an unknown one.
+const DefaultLocale = "en"
+
+// GreetResult describes a localized greeting.
+type GreetResult struct {
+	Greeting string
+	// Locale is the tag of the template that was actually used.
+	Locale string
+	// FallbackOccurred reports whether the requested locale was unknown
+	// and DefaultLocale was used instead.
+	FallbackOccurred bool
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]*template.Template{}
+)
+
+func init() {
+	for tag, tmpl := range map[string]string{
+		"en": "Hello {{.Name}}",
+		"es": "Hola {{.Name}}",
+		"fr": "Bonjour {{.Name}}",
+		"de": "Hallo {{.Name}}",
+		"ja": "こんにちは{{.Name}}さん",
+		"zh": "你好{{.Name}}",
+		"pt": "Olá {{.Name}}",
+	} {
+		if err := RegisterLocale(tag, tmpl); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterLocale adds or replaces the greeting template for an IETF
+// language tag. The template is parsed with text/template and executed
+// against a TemplateData value, and must be valid UTF-8.
+func RegisterLocale(tag, tmpl string) error {
+	tag = normalizeTag(tag)
+	if tag == "" {
+		return fmt.Errorf("greet: empty locale tag")
+	}
+	if !utf8.ValidString(tmpl) {
+		return fmt.Errorf("greet: locale %q: template is not valid UTF-8", tag)
+	}
+	t, err := template.New(tag).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("greet: locale %q: %w", tag, err)
+	}
+	localesMu.Lock()
+	locales[tag] = t
+	localesMu.Unlock()
+	return nil
+}
+
+// GreetLocale greets name using the template registered for locale.
+func GreetLocale(name, locale string) GreetResult {
+	return SimpleGreeter{Name: name, Locale: locale}.GreetLocale()
+}
+
+// GreetLocale greets g.Name using the template registered for g.Locale,
+// falling back to DefaultLocale when the locale is unknown.
+func (g SimpleGreeter) GreetLocale() GreetResult {
+	t, tag, fallback := lookupLocale(g.Locale)
+	var b strings.Builder
+	if err := t.Execute(&b, g.templateData(tag)); err != nil {
+		return GreetResult{
+			Greeting:         fmt.Sprintf("Hello %s", g.addressee()),
+			Locale:           DefaultLocale,
+			FallbackOccurred: true,
+		}
+	}
+	return GreetResult{Greeting: b.String(), Locale: tag, FallbackOccurred: fallback}
+}
+
+// lookupLocale resolves tag to a registered template, trying the exact tag,
+// then its primary language subtag, then DefaultLocale.
+func lookupLocale(tag string) (*template.Template, string, bool) {
+	tag = normalizeTag(tag)
+	if tag == "" {
+		tag = DefaultLocale
+	}
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	if t, ok := locales[tag]; ok {
+		return t, tag, false
+	}
+	if base, _, ok := strings.Cut(tag, "-"); ok {
+		if t, ok := locales[base]; ok {
+			return t, base, false
+		}
+	}
+	return locales[DefaultLocale], DefaultLocale, true
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
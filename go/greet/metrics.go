@@ -0,0 +1,103 @@
+package greet
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxNameLabelLength bounds the length of the name label to keep metric
+// cardinality in check.
+const maxNameLabelLength = 32
+
+// Metrics records greeting calls. A greeter without metrics skips
+// instrumentation entirely.
+type Metrics interface {
+	// ObserveGreet records one greeting for name that took d. locale is
+	// the tag of the registered locale that was used, never an
+	// unresolved caller-supplied tag.
+	ObserveGreet(locale, name string, d time.Duration)
+}
+
+// NewNoOpMetrics returns a Metrics that discards all observations.
+func NewNoOpMetrics() Metrics {
+	return noOpMetrics{}
+}
+
+type noOpMetrics struct{}
+
+func (noOpMetrics) ObserveGreet(string, string, time.Duration) {}
+
+// prometheusMetrics counts and times greetings by locale and name.
+type prometheusMetrics struct {
+	calls    *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewPrometheusMetrics returns a Metrics registered with reg. Collectors
+// already registered by another greeter are shared.
+func NewPrometheusMetrics(reg prometheus.Registerer) (Metrics, error) {
+	labels := []string{"locale", "name"}
+	calls, err := registerCollector(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "greeter_greet_total",
+		Help: "Number of greetings generated.",
+	}, labels))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := registerCollector(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "greeter_greet_duration_seconds",
+		Help:    "Time taken to generate a greeting.",
+		Buckets: prometheus.ExponentialBuckets(1e-6, 4, 10),
+	}, labels))
+	if err != nil {
+		return nil, err
+	}
+	return &prometheusMetrics{calls: calls, duration: duration}, nil
+}
+
+// registerCollector registers c with reg, returning the existing collector
+// if an identical one is already registered.
+func registerCollector[C prometheus.Collector](reg prometheus.Registerer, c C) (C, error) {
+	if err := reg.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(C); ok {
+				return existing, nil
+			}
+		}
+		return c, err
+	}
+	return c, nil
+}
+
+func (m *prometheusMetrics) ObserveGreet(locale, name string, d time.Duration) {
+	if r := []rune(name); len(r) > maxNameLabelLength {
+		name = string(r[:maxNameLabelLength])
+	}
+	m.calls.WithLabelValues(locale, name).Inc()
+	m.duration.WithLabelValues(locale, name).Observe(d.Seconds())
+}
+
+// WithMetrics records a call counter and duration histogram for every
+// greeting, registered with reg.
+func WithMetrics(reg prometheus.Registerer) Option {
+	return func(g *SimpleGreeter) error {
+		m, err := NewPrometheusMetrics(reg)
+		if err != nil {
+			return err
+		}
+		g.metrics = m
+		return nil
+	}
+}
+
+// WithMetricsRecorder records greetings with m, such as NewNoOpMetrics in
+// tests.
+func WithMetricsRecorder(m Metrics) Option {
+	return func(g *SimpleGreeter) error {
+		g.metrics = m
+		return nil
+	}
+}
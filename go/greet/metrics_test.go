@@ -0,0 +1,89 @@
+package greet
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWithMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	// Both greeters register the same collectors; the second must reuse the
+	// first's through AlreadyRegisteredError.
+	ada, err := NewGreeter("Ada", WithLocale("fr-CA"), WithMetrics(reg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	long := strings.Repeat("é", 40)
+	other, err := NewGreeter(long, WithMetrics(reg))
+	if err != nil {
+		t.Fatalf("second WithMetrics on one registry: %v", err)
+	}
+	ada.Greet()
+	ada.Greet()
+	other.Greet()
+
+	want := `
+# HELP greeter_greet_total Number of greetings generated.
+# TYPE greeter_greet_total counter
+greeter_greet_total{locale="en",name="` + strings.Repeat("é", maxNameLabelLength) + `"} 1
+greeter_greet_total{locale="fr",name="Ada"} 2
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "greeter_greet_total"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(reg, "greeter_greet_duration_seconds"); n != 2 {
+		t.Errorf("duration series = %d, want 2", n)
+	}
+}
+
+func TestWithMetricsRegistrationConflict(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "greeter_greet_total",
+		Help: "Something else.",
+	}))
+	if _, err := NewGreeter("Ada", WithMetrics(reg)); err == nil {
+		t.Error("WithMetrics over a conflicting collector succeeded")
+	}
+}
+
+// recordingMetrics remembers the labels of every observation.
+type recordingMetrics struct {
+	locales, names []string
+}
+
+func (m *recordingMetrics) ObserveGreet(locale, name string, d time.Duration) {
+	m.locales = append(m.locales, locale)
+	m.names = append(m.names, name)
+}
+
+func TestMetricsLocaleLabel(t *testing.T) {
+	m := &recordingMetrics{}
+	g, err := NewGreeter("Ada", WithMetricsRecorder(m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, locale := range []string{"", "de-AT", "pt_BR", "xx-unknown", "fr"} {
+		g.Locale = locale
+		g.Greet()
+	}
+	want := []string{"en", "de", "pt", "en", "fr"}
+	if !slices.Equal(m.locales, want) {
+		t.Errorf("locale labels = %q, want %q", m.locales, want)
+	}
+}
+
+func TestNoOpMetrics(t *testing.T) {
+	g, err := NewGreeter("Ada", WithMetricsRecorder(NewNoOpMetrics()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Greet(); got != "Hello Ada" {
+		t.Errorf("Greet() = %q, want Hello Ada", got)
+	}
+}
@@ -0,0 +1,55 @@
+package greet
+
+import (
+	"context"
+	"strings"
+)
+
+// GreeterMiddleware wraps a Greeter to transform its greetings.
+type GreeterMiddleware func(next Greeter) Greeter
+
+// Chain wraps base with mw so that the middlewares transform the greeting
+// in the order given. With no middleware, base is returned unchanged.
+func Chain(base Greeter, mw ...GreeterMiddleware) Greeter {
+	g := base
+	for _, m := range mw {
+		g = m(g)
+	}
+	return g
+}
+
+// UppercaseMiddleware upper-cases greetings.
+func UppercaseMiddleware(next Greeter) Greeter {
+	return transformGreeter{next: next, fn: strings.ToUpper}
+}
+
+// TrimSpaceMiddleware strips leading and trailing white space from
+// greetings.
+func TrimSpaceMiddleware(next Greeter) Greeter {
+	return transformGreeter{next: next, fn: strings.TrimSpace}
+}
+
+// PrefixMiddleware prepends prefix to greetings, e.g. "STAGING: ".
+func PrefixMiddleware(prefix string) GreeterMiddleware {
+	return func(next Greeter) Greeter {
+		return transformGreeter{next: next, fn: func(s string) string { return prefix + s }}
+	}
+}
+
+// transformGreeter applies fn to the greetings of next.
+type transformGreeter struct {
+	next Greeter
+	fn   func(string) string
+}
+
+func (t transformGreeter) Greet() string {
+	return t.fn(t.next.Greet())
+}
+
+func (t transformGreeter) GreetContext(ctx context.Context, name string) (string, error) {
+	s, err := GreetName(ctx, t.next, name)
+	if err != nil {
+		return "", err
+	}
+	return t.fn(s), nil
+}
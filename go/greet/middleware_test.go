@@ -0,0 +1,46 @@
+package greet
+
+import (
+	"context"
+	"testing"
+)
+
+// recordMiddleware appends tag to the greeting, so the result shows the
+// order middlewares ran in.
+func recordMiddleware(tag string) GreeterMiddleware {
+	return func(next Greeter) Greeter {
+		return transformGreeter{next: next, fn: func(s string) string { return s + " " + tag }}
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	g := Chain(SimpleGreeter{Name: "Ada"}, recordMiddleware("1"), recordMiddleware("2"), recordMiddleware("3"))
+	if got, want := g.Greet(), "Hello Ada 1 2 3"; got != want {
+		t.Errorf("Greet = %q, want %q", got, want)
+	}
+	got, err := g.(GreeterWithError).GreetContext(context.Background(), "Grace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Hello Grace 1 2 3"; got != want {
+		t.Errorf("GreetContext = %q, want %q", got, want)
+	}
+}
+
+func TestChainBuiltins(t *testing.T) {
+	g := Chain(SimpleGreeter{Name: " ada "}, PrefixMiddleware("> "), UppercaseMiddleware, TrimSpaceMiddleware)
+	if got, want := g.Greet(), "> HELLO  ADA"; got != want {
+		t.Errorf("Greet = %q, want %q", got, want)
+	}
+}
+
+func TestChainEmptyIsPassThrough(t *testing.T) {
+	base := SimpleGreeter{Name: "Ada"}
+	g := Chain(base)
+	if _, ok := g.(SimpleGreeter); !ok {
+		t.Fatalf("Chain with no middleware returned %T, want the base greeter", g)
+	}
+	if got, want := g.Greet(), base.Greet(); got != want {
+		t.Errorf("Greet = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+package greet
+
+import "strings"
+
+// DefaultConjunction joins the last two names in GreetMultiple.
+const DefaultConjunction = "and"
+
+// GreetMultiple greets several names at once, listing them with an Oxford
+// comma: "Hello Alice, Bob, and Carol". The greeting prefix follows
+// g.Locale while the conjunction comes from g.Conjunction. Salutation and
+// Suffix are not applied. Each name is normalized as set by
+// WithNormalization and marked up by g.Format separately, so the
+// conjunction and commas stay plain. It returns "" if names is empty.
+func (g SimpleGreeter) GreetMultiple(names ...string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	conj := g.Conjunction
+	if conj == "" {
+		conj = DefaultConjunction
+	}
+	g.Salutation, g.Suffix = "", ""
+	formatted := make([]string, len(names))
+	for i, name := range names {
+		formatted[i] = g.Format.apply(validUTF8(g.norm.Apply(name, g.Locale)))
+	}
+	g.Name = joinNames(formatted, conj)
+	g.norm, g.Format = NormOpts{}, FormatPlain
+	return g.Greet()
+}
+
+func joinNames(names []string, conj string) string {
+	switch len(names) {
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " " + conj + " " + names[1]
+	default:
+		last := len(names) - 1
+		return strings.Join(names[:last], ", ") + ", " + conj + " " + names[last]
+	}
+}
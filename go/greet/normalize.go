@@ -0,0 +1,62 @@
+package greet
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormOpts selects the steps NormalizeName-style normalization applies.
+// The zero value leaves names unchanged.
+type NormOpts struct {
+	// TrimSpace removes leading and trailing white space.
+	TrimSpace bool
+	// NFCNormalize converts the name to Unicode Normalization Form C, so
+	// that composed and decomposed accents compare and print alike.
+	NFCNormalize bool
+	// TitleCase title-cases each word using the rules of the greeter's
+	// locale, e.g. "ALICE" becomes "Alice".
+	TitleCase bool
+}
+
+// DefaultNormOpts trims names and converts them to NFC. Title-casing is
+// left out because it mangles names such as "McDonald"; enable it
+// explicitly if wanted.
+var DefaultNormOpts = NormOpts{TrimSpace: true, NFCNormalize: true}
+
+// NormalizeName trims name and converts it to NFC. Use NormOpts.Apply to
+// choose other steps, such as title-casing, or a locale.
+func NormalizeName(name string) string {
+	return DefaultNormOpts.Apply(name, DefaultLocale)
+}
+
+// Apply normalizes name with the steps enabled in o. locale is an IETF
+// language tag used as a hint for title-casing; empty means DefaultLocale.
+func (o NormOpts) Apply(name, locale string) string {
+	if o.TrimSpace {
+		name = strings.TrimSpace(name)
+	}
+	if o.NFCNormalize {
+		name = norm.NFC.String(name)
+	}
+	if o.TitleCase {
+		if locale == "" {
+			locale = DefaultLocale
+		}
+		name = cases.Title(language.Make(locale)).String(name)
+	}
+	return name
+}
+
+// WithNormalization normalizes the name with opts wherever g renders it:
+// Greet, GreetContext, GreetLocale, GreetWithTime, GreetTemplate and the
+// other greeting methods. GreetMultiple normalizes each name separately.
+// Metrics, logs and events record the name as given.
+func WithNormalization(opts NormOpts) Option {
+	return func(g *SimpleGreeter) error {
+		g.norm = opts
+		return nil
+	}
+}
@@ -0,0 +1,57 @@
+package greet
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNormalizeName(t *testing.T) {
+	for _, tc := range []struct{ in, want string }{
+		{"  Alice  ", "Alice"},
+		{"McDonald", "McDonald"},
+		{"ALICE", "ALICE"},
+		{"álicé", "álicé"},
+	} {
+		if got := NormalizeName(tc.in); got != tc.want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestNormOptsTitleCase(t *testing.T) {
+	o := NormOpts{TrimSpace: true, TitleCase: true}
+	if got := o.Apply("  ada LOVELACE ", "en"); got != "Ada Lovelace" {
+		t.Errorf("Apply = %q, want %q", got, "Ada Lovelace")
+	}
+}
+
+func TestWithNormalizationAppliesToEveryGreeting(t *testing.T) {
+	g, err := NewGreeter("  ada  ", WithNormalization(NormOpts{TrimSpace: true, TitleCase: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	noon := time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC)
+	ctxGreeting, err := g.GreetContext(context.Background(), " grace ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	withTemplate := *g
+	withTemplate.Template = "Hi {{.Name}}"
+	tmplGreeting, err := withTemplate.GreetTemplate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct{ name, got, want string }{
+		{"Greet", g.Greet(), "Hello Ada"},
+		{"GreetContext", ctxGreeting, "Hello Grace"},
+		{"GreetLocale", g.GreetLocale().Greeting, "Hello Ada"},
+		{"GreetWithTime", g.GreetWithTime(noon), "Good afternoon Ada"},
+		{"GreetTemplate", tmplGreeting, "Hi Ada"},
+		{"GreetMultiple", g.GreetMultiple(" ada", "grace "), "Hello Ada and Grace"},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s = %q, want %q", tc.name, tc.got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package greet
+
+import "sync"
+
+// GreetOnce caches greetings by name so each is computed at most once
+// until invalidated. The zero value is ready to use. A GreetOnce must not
+// be copied after first use.
+type GreetOnce struct {
+	cache sync.Map // name -> *onceEntry
+}
+
+type onceEntry struct {
+	once     sync.Once
+	greeting string
+}
+
+// Greet returns the cached greeting for name, computing it with Greet on
+// the first call.
+func (o *GreetOnce) Greet(name string) string {
+	v, ok := o.cache.Load(name)
+	if !ok {
+		v, _ = o.cache.LoadOrStore(name, new(onceEntry))
+	}
+	e := v.(*onceEntry)
+	e.once.Do(func() { e.greeting = Greet(name) })
+	return e.greeting
+}
+
+// Invalidate evicts the cached greeting for name.
+func (o *GreetOnce) Invalidate(name string) {
+	o.cache.Delete(name)
+}
+
+// InvalidateAll evicts every cached greeting.
+func (o *GreetOnce) InvalidateAll() {
+	o.cache.Clear()
+}
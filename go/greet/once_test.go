@@ -0,0 +1,75 @@
+package greet
+
+import (
+	"sync"
+	"testing"
+)
+
+// setDefaultTemplate registers tmpl for DefaultLocale until the test ends,
+// so tests can tell a fresh greeting from a cached one.
+func setDefaultTemplate(t *testing.T, tmpl string) {
+	t.Helper()
+	if err := RegisterLocale(DefaultLocale, tmpl); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { RegisterLocale(DefaultLocale, "Hello {{.Name}}") })
+}
+
+func TestGreetOnceCaches(t *testing.T) {
+	var o GreetOnce
+	if got := o.Greet("Ada"); got != "Hello Ada" {
+		t.Fatalf("Greet(Ada) = %q, want Hello Ada", got)
+	}
+	setDefaultTemplate(t, "Hi {{.Name}}")
+	if got := o.Greet("Ada"); got != "Hello Ada" {
+		t.Errorf("second Greet(Ada) = %q, want the cached Hello Ada", got)
+	}
+	if got := o.Greet("Bob"); got != "Hi Bob" {
+		t.Errorf("Greet(Bob) = %q, want a fresh Hi Bob", got)
+	}
+}
+
+func TestGreetOnceInvalidate(t *testing.T) {
+	var o GreetOnce
+	o.Greet("Ada")
+	o.Greet("Bob")
+	setDefaultTemplate(t, "Hi {{.Name}}")
+
+	o.Invalidate("Ada")
+	if got := o.Greet("Ada"); got != "Hi Ada" {
+		t.Errorf("Greet(Ada) after Invalidate = %q, want Hi Ada", got)
+	}
+	if got := o.Greet("Bob"); got != "Hello Bob" {
+		t.Errorf("Greet(Bob) after Invalidate(Ada) = %q, want the cached Hello Bob", got)
+	}
+	o.Invalidate("never-greeted")
+
+	setDefaultTemplate(t, "Yo {{.Name}}")
+	o.InvalidateAll()
+	for name, want := range map[string]string{"Ada": "Yo Ada", "Bob": "Yo Bob"} {
+		if got := o.Greet(name); got != want {
+			t.Errorf("Greet(%s) after InvalidateAll = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGreetOnceConcurrent(t *testing.T) {
+	var o GreetOnce
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 100 {
+				if got := o.Greet("Ada"); got != "Hello Ada" {
+					t.Errorf("Greet(Ada) = %q, want Hello Ada", got)
+					return
+				}
+			}
+		})
+	}
+	wg.Go(func() {
+		for range 100 {
+			o.Invalidate("Ada")
+		}
+	})
+	wg.Wait()
+}
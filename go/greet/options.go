@@ -0,0 +1,103 @@
+package greet
+
+import (
+	"fmt"
+	"log/slog"
+
+	"golang.org/x/time/rate"
+)
+
+// Option configures a SimpleGreeter built by NewGreeter.
+type Option func(*SimpleGreeter) error
+
+// NewGreeter returns a greeter for name configured by opts. It returns an
+// error if name fails strict validation against the greeter's
+// MaxNameLength.
+func NewGreeter(name string, opts ...Option) (*SimpleGreeter, error) {
+	g := &SimpleGreeter{Name: name, MaxNameLength: DefaultMaxNameLength}
+	for _, opt := range opts {
+		if err := opt(g); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateName(name, g.MaxNameLength); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// WithLocale sets the greeter's locale tag.
+func WithLocale(tag string) Option {
+	return func(g *SimpleGreeter) error {
+		g.Locale = tag
+		return nil
+	}
+}
+
+// WithSalutation sets the title placed before the name, such as "Dr.".
+func WithSalutation(s string) Option {
+	return func(g *SimpleGreeter) error {
+		g.Salutation = s
+		return nil
+	}
+}
+
+// WithSuffix sets the suffix placed after the name, such as "Jr.".
+func WithSuffix(s string) Option {
+	return func(g *SimpleGreeter) error {
+		g.Suffix = s
+		return nil
+	}
+}
+
+// WithConjunction sets the word joining the last two names in
+// GreetMultiple, such as "y" or "und".
+func WithConjunction(word string) Option {
+	return func(g *SimpleGreeter) error {
+		g.Conjunction = word
+		return nil
+	}
+}
+
+// WithFormat sets how the name is marked up in greetings.
+func WithFormat(f Format) Option {
+	return func(g *SimpleGreeter) error {
+		g.Format = f
+		return nil
+	}
+}
+
+// WithMaxNameLength sets the maximum accepted name length in bytes.
+func WithMaxNameLength(n int) Option {
+	return func(g *SimpleGreeter) error {
+		if n <= 0 {
+			return fmt.Errorf("greet: invalid max name length %d", n)
+		}
+		g.MaxNameLength = n
+		return nil
+	}
+}
+
+// WithLogger logs a debug event for every greeting to l. Greeters without
+// a logger do not log at all.
+func WithLogger(l *slog.Logger) Option {
+	return func(g *SimpleGreeter) error {
+		g.logger = l
+		return nil
+	}
+}
+
+// WithRateLimit limits the greeter to rps greetings per second. Greet
+// blocks until it may proceed; GreetContext also returns early with
+// ctx.Err() if ctx is done while waiting, or context.DeadlineExceeded as
+// soon as it is clear that ctx's deadline would pass first. Greeters have
+// no rate limit by default.
+func WithRateLimit(rps int) Option {
+	return func(g *SimpleGreeter) error {
+		if rps <= 0 {
+			return fmt.Errorf("greet: invalid rate limit %d", rps)
+		}
+		g.limiter = rate.NewLimiter(rate.Limit(rps), 1)
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimitRejectsNonPositive(t *testing.T) {
+	for _, rps := range []int{0, -1} {
+		if _, err := NewGreeter("Ada", WithRateLimit(rps)); err == nil {
+			t.Errorf("WithRateLimit(%d) accepted", rps)
+		}
+	}
+}
+
+func TestWithRateLimitBlocks(t *testing.T) {
+	g, err := NewGreeter("Ada", WithRateLimit(20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for range 3 {
+		if got := g.Greet(); got != "Hello Ada" {
+			t.Fatalf("Greet() = %q, want %q", got, "Hello Ada")
+		}
+	}
+	// The first token is available at once; the next two take 50ms each.
+	if d := time.Since(start); d < 90*time.Millisecond {
+		t.Errorf("3 greetings at 20 rps took %v, want about 100ms", d)
+	}
+}
+
+func TestWithRateLimitCancel(t *testing.T) {
+	g, err := NewGreeter("Ada", WithRateLimit(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Greet() // take the only token
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	if _, err := g.GreetContext(ctx, "Bob"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GreetContext after cancel = %v, want context.Canceled", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("GreetContext returned %v after cancel, want promptly", d)
+	}
+
+	if _, err := g.GreetContext(ctx, "Bob"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GreetContext with a done context = %v, want context.Canceled", err)
+	}
+}
+
+func TestWithRateLimitDeadline(t *testing.T) {
+	g, err := NewGreeter("Ada", WithRateLimit(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Greet()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = g.GreetContext(ctx, "Bob")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GreetContext past the deadline = %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("GreetContext took %v, want it to give up before the next token", d)
+	}
+}
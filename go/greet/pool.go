@@ -0,0 +1,36 @@
+package greet
+
+import "sync"
+
+// GreeterPool hands out reusable greeters that share a base configuration.
+// Each pooled greeter is a Clone of the base, so greeters never share
+// mutable state.
+type GreeterPool struct {
+	pool sync.Pool
+}
+
+// NewGreeterPool returns a pool of greeters configured like base.
+func NewGreeterPool(base SimpleGreeter) *GreeterPool {
+	p := &GreeterPool{}
+	p.pool.New = func() any {
+		g := base.Clone()
+		g.Name = ""
+		return g
+	}
+	return p
+}
+
+// Get returns a greeter for name. Callers should change only its Name and
+// hand it back with Put when done.
+func (p *GreeterPool) Get(name string) *SimpleGreeter {
+	g := p.pool.Get().(*SimpleGreeter)
+	g.Name = name
+	return g
+}
+
+// Put clears g's Name and returns it to the pool. g must not be used
+// afterwards.
+func (p *GreeterPool) Put(g *SimpleGreeter) {
+	g.Name = ""
+	p.pool.Put(g)
+}
@@ -0,0 +1,32 @@
+package greet
+
+import "testing"
+
+// BenchmarkGreeterPool compares greeting through a GreeterPool with
+// cloning the base greeter for every greeting, which is what the pool
+// saves.
+func BenchmarkGreeterPool(b *testing.B) {
+	base := SimpleGreeter{
+		Locale:        "fr",
+		Salutation:    "Dr.",
+		RandomPhrases: []string{"Bonjour %s", "Salut %s"},
+		TimeConfig:    &TimeGreeterConfig{MorningStart: 6, AfternoonStart: 12, EveningStart: 18},
+	}
+	b.Run("Pool", func(b *testing.B) {
+		p := NewGreeterPool(base)
+		b.ReportAllocs()
+		for range b.N {
+			g := p.Get("Curie")
+			g.Greet()
+			p.Put(g)
+		}
+	})
+	b.Run("NoPool", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			g := base.Clone()
+			g.Name = "Curie"
+			g.Greet()
+		}
+	})
+}
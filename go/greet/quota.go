@@ -0,0 +1,110 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrQuotaExceeded is returned by GreeterQuota when a name has been greeted
+// too often in the current window.
+var ErrQuotaExceeded = errors.New("greet: quota exceeded")
+
+// GreeterQuota wraps a Greeter, allowing each name at most a fixed number
+// of greetings per time window. Close stops the window timer.
+type GreeterQuota struct {
+	g       Greeter
+	perName int64
+	counts  sync.Map // name -> *atomic.Int64
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ GreeterWithError = (*GreeterQuota)(nil)
+
+// NewGreeterQuota returns g limited to perName greetings of each name per
+// window. Counts are reset together at the end of every window. It panics
+// if perName is negative or window is not positive.
+func NewGreeterQuota(g Greeter, perName int, window time.Duration) *GreeterQuota {
+	if perName < 0 {
+		panic(fmt.Sprintf("greet: NewGreeterQuota: negative perName %d", perName))
+	}
+	if window <= 0 {
+		panic(fmt.Sprintf("greet: NewGreeterQuota: non-positive window %v", window))
+	}
+	q := &GreeterQuota{
+		g:       g,
+		perName: int64(perName),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go q.resetEvery(window)
+	return q
+}
+
+// Greet returns the wrapped greeter's own greeting, counted under the empty
+// name, or "" once that quota is used up.
+func (q *GreeterQuota) Greet() string {
+	if !q.take("") {
+		return ""
+	}
+	return q.g.Greet()
+}
+
+// GreetContext greets name with the wrapped greeter, or returns an error
+// wrapping ErrQuotaExceeded if name has no quota left in this window.
+func (q *GreeterQuota) GreetContext(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if !q.take(name) {
+		return "", fmt.Errorf("%w for %q", ErrQuotaExceeded, name)
+	}
+	return GreetName(ctx, q.g, name)
+}
+
+// QuotaRemaining returns how many more times name can be greeted in the
+// current window.
+func (q *GreeterQuota) QuotaRemaining(name string) int {
+	n := int64(0)
+	if c, ok := q.counts.Load(name); ok {
+		n = c.(*atomic.Int64).Load()
+	}
+	return int(max(q.perName-n, 0))
+}
+
+// Reset restores the full quota for name.
+func (q *GreeterQuota) Reset(name string) {
+	q.counts.Delete(name)
+}
+
+// Close stops the window timer. It is safe to call more than once.
+func (q *GreeterQuota) Close() error {
+	q.closeOnce.Do(func() { close(q.stop) })
+	<-q.done
+	return nil
+}
+
+func (q *GreeterQuota) take(name string) bool {
+	c, _ := q.counts.LoadOrStore(name, new(atomic.Int64))
+	return c.(*atomic.Int64).Add(1) <= q.perName
+}
+
+func (q *GreeterQuota) resetEvery(window time.Duration) {
+	defer close(q.done)
+	t := time.NewTicker(window)
+	defer t.Stop()
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-t.C:
+			q.counts.Clear()
+		}
+	}
+}
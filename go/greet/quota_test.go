@@ -0,0 +1,49 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewGreeterQuotaPanicsOnInvalidArguments(t *testing.T) {
+	for _, tc := range []struct {
+		perName int
+		window  time.Duration
+	}{
+		{1, 0},
+		{1, -time.Second},
+		{-1, time.Second},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewGreeterQuota(%d, %v) did not panic", tc.perName, tc.window)
+				}
+			}()
+			NewGreeterQuota(SimpleGreeter{}, tc.perName, tc.window).Close()
+		}()
+	}
+}
+
+func TestGreeterQuota(t *testing.T) {
+	q := NewGreeterQuota(SimpleGreeter{}, 2, time.Hour)
+	defer q.Close()
+	ctx := context.Background()
+	for range 2 {
+		if _, err := q.GreetContext(ctx, "Ada"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := q.GreetContext(ctx, "Ada"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("third GreetContext = %v, want ErrQuotaExceeded", err)
+	}
+	if n := q.QuotaRemaining("Grace"); n != 2 {
+		t.Errorf("QuotaRemaining(Grace) = %d, want 2", n)
+	}
+	q.Reset("Ada")
+	if n := q.QuotaRemaining("Ada"); n != 2 {
+		t.Errorf("QuotaRemaining(Ada) after Reset = %d, want 2", n)
+	}
+}
@@ -0,0 +1,29 @@
+package greet
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// GreetRandom greets name with a phrase picked uniformly at random from
+// phrases. Each phrase may contain %s or {{.Name}} where the name goes. An
+// empty list falls back to Greet.
+func GreetRandom(name string, phrases []string) string {
+	if len(phrases) == 0 {
+		return Greet(name)
+	}
+	return fillPhrase(phrases[rand.Intn(len(phrases))], name)
+}
+
+// GreetRandomWithSource is like GreetRandom but draws from src, so results
+// are reproducible for a given seed. src is not safe for concurrent use.
+func GreetRandomWithSource(name string, phrases []string, src rand.Source) string {
+	if len(phrases) == 0 {
+		return Greet(name)
+	}
+	return fillPhrase(phrases[rand.New(src).Intn(len(phrases))], name)
+}
+
+func fillPhrase(phrase, name string) string {
+	return validUTF8(strings.NewReplacer("%s", name, "{{.Name}}", name).Replace(phrase))
+}
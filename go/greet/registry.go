@@ -0,0 +1,108 @@
+package greet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// ErrEmptyKey is returned when registering or saving a greeter under an
+// empty key.
+var ErrEmptyKey = errors.New("greet: empty registry key")
+
+// Registry holds named greeters. It is safe for concurrent use and its
+// zero value is ready to use.
+type Registry struct {
+	mu       sync.RWMutex
+	greeters map[string]Greeter
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register stores g under key, replacing any greeter already there.
+func (r *Registry) Register(key string, g Greeter) error {
+	if key == "" {
+		return ErrEmptyKey
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.greeters == nil {
+		r.greeters = make(map[string]Greeter)
+	}
+	r.greeters[key] = g
+	return nil
+}
+
+// Lookup returns the greeter registered under key.
+func (r *Registry) Lookup(key string) (Greeter, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	g, ok := r.greeters[key]
+	return g, ok
+}
+
+// Unregister removes the greeter registered under key, if any.
+func (r *Registry) Unregister(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.greeters, key)
+}
+
+// Keys returns the registered keys in sorted order.
+func (r *Registry) Keys() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]string, 0, len(r.greeters))
+	for k := range r.greeters {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// LoadFromJSON registers the greeters in a JSON array read from rd. Each
+// element is a SimpleGreeter object with an additional "key" field:
+//
+//	[{"key": "emea", "name": "Alice", "locale": "fr"}]
+//
+// Nothing is registered if any element is invalid.
+func (r *Registry) LoadFromJSON(rd io.Reader) error {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(rd).Decode(&raw); err != nil {
+		return fmt.Errorf("greet: registry: %w", err)
+	}
+	keys := make([]string, len(raw))
+	greeters := make([]*SimpleGreeter, len(raw))
+	for i, msg := range raw {
+		var k struct {
+			Key string `json:"key"`
+		}
+		if err := json.Unmarshal(msg, &k); err != nil {
+			return fmt.Errorf("greet: registry entry %d: %w", i, err)
+		}
+		if k.Key == "" {
+			return fmt.Errorf("greet: registry entry %d: %w", i, ErrEmptyKey)
+		}
+		g := new(SimpleGreeter)
+		if err := json.Unmarshal(msg, g); err != nil {
+			return fmt.Errorf("greet: registry entry %q: %w", k.Key, err)
+		}
+		keys[i], greeters[i] = k.Key, g
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.greeters == nil {
+		r.greeters = make(map[string]Greeter, len(keys))
+	}
+	for i, k := range keys {
+		r.greeters[k] = greeters[i]
+	}
+	return nil
+}
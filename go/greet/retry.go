@@ -0,0 +1,69 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy controls how GreetWithRetry retries failed greetings.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts; values below one mean
+	// a single attempt.
+	MaxAttempts int
+
+	// InitialDelay is the wait before the second attempt. Each later wait
+	// is the previous one times Multiplier, capped at MaxDelay if it is
+	// positive. Multipliers below one are treated as one. Every wait is
+	// jittered by up to 10% either way.
+	InitialDelay time.Duration
+	Multiplier   float64
+	MaxDelay     time.Duration
+
+	// RetryOn reports whether an error is worth retrying. Nil retries
+	// every error except context cancellation and deadline expiry.
+	RetryOn func(error) bool
+}
+
+// GreetWithRetry greets name with g, retrying failures according to
+// policy. If every attempt fails it returns the last error, annotated with
+// the number of attempts made; if ctx is done while waiting it returns
+// ctx.Err().
+func GreetWithRetry(ctx context.Context, g Greeter, name string, policy RetryPolicy) (string, error) {
+	attempts := max(policy.MaxAttempts, 1)
+	retryOn := policy.RetryOn
+	if retryOn == nil {
+		retryOn = func(err error) bool {
+			return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+		}
+	}
+	delay := policy.InitialDelay
+	for attempt := 1; ; attempt++ {
+		s, err := GreetName(ctx, g, name)
+		if err == nil {
+			return s, nil
+		}
+		if attempt == attempts || !retryOn(err) {
+			return "", fmt.Errorf("greet: %d attempt(s): %w", attempt, err)
+		}
+
+		t := time.NewTimer(jitter(delay))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return "", ctx.Err()
+		case <-t.C:
+		}
+		delay = time.Duration(float64(delay) * max(policy.Multiplier, 1))
+		if policy.MaxDelay > 0 {
+			delay = min(delay, policy.MaxDelay)
+		}
+	}
+}
+
+// jitter returns d adjusted randomly by up to 10% either way.
+func jitter(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * (0.9 + 0.2*rand.Float64()))
+}
@@ -0,0 +1,129 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// failingGreeter fails the first n calls with err and then greets.
+func failingGreeter(n int, err error, calls *int) contextGreeter {
+	return func(ctx context.Context, name string) (string, error) {
+		*calls++
+		if *calls <= n {
+			return "", err
+		}
+		return "Hello " + name, nil
+	}
+}
+
+// plainGreeter implements only Greeter.
+type plainGreeter string
+
+func (p plainGreeter) Greet() string { return string(p) }
+
+var errTransient = errors.New("transient")
+
+func TestGreetWithRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		fail      int
+		err       error
+		policy    RetryPolicy
+		want      string
+		wantErr   string
+		wantCalls int
+	}{
+		{"success", 0, nil, RetryPolicy{MaxAttempts: 3}, "Hello Ada", "", 1},
+		{"recovers", 2, errTransient, RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond}, "Hello Ada", "", 3},
+		{"exhausted", 5, errTransient, RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond}, "", "greet: 3 attempt(s): transient", 3},
+		{"zero attempts", 5, errTransient, RetryPolicy{}, "", "greet: 1 attempt(s): transient", 1},
+		{
+			"RetryOn false", 5, errTransient,
+			RetryPolicy{MaxAttempts: 3, RetryOn: func(error) bool { return false }},
+			"", "greet: 1 attempt(s): transient", 1,
+		},
+		{"default skips Canceled", 5, context.Canceled, RetryPolicy{MaxAttempts: 3}, "", "greet: 1 attempt(s): context canceled", 1},
+		{"default skips DeadlineExceeded", 5, context.DeadlineExceeded, RetryPolicy{MaxAttempts: 3}, "", "greet: 1 attempt(s): context deadline exceeded", 1},
+	}
+	for _, tt := range tests {
+		calls := 0
+		got, err := GreetWithRetry(context.Background(), failingGreeter(tt.fail, tt.err, &calls), "Ada", tt.policy)
+		if got != tt.want {
+			t.Errorf("%s: greeting = %q, want %q", tt.name, got, tt.want)
+		}
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: error = %v, want nil", tt.name, err)
+		case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		case tt.wantErr != "" && !errors.Is(err, tt.err):
+			t.Errorf("%s: error %v does not wrap %v", tt.name, err, tt.err)
+		}
+		if calls != tt.wantCalls {
+			t.Errorf("%s: %d calls, want %d", tt.name, calls, tt.wantCalls)
+		}
+	}
+}
+
+func TestGreetWithRetryPlainGreeter(t *testing.T) {
+	got, err := GreetWithRetry(context.Background(), plainGreeter("Hi"), "Ada", RetryPolicy{})
+	if got != "Hi" || err != nil {
+		t.Errorf("GreetWithRetry(plain) = %q, %v; want Hi, nil", got, err)
+	}
+}
+
+func TestGreetWithRetryMaxDelay(t *testing.T) {
+	calls := 0
+	policy := RetryPolicy{
+		MaxAttempts:  4,
+		InitialDelay: time.Millisecond,
+		Multiplier:   1000,
+		MaxDelay:     5 * time.Millisecond,
+	}
+	start := time.Now()
+	_, err := GreetWithRetry(context.Background(), failingGreeter(10, errTransient, &calls), "Ada", policy)
+	if err == nil || calls != 4 {
+		t.Fatalf("GreetWithRetry = %v after %d calls, want an error after 4", err, calls)
+	}
+	// Uncapped, the waits would be 1ms, 1s and 1000s.
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("4 attempts took %v, want the waits capped at 5ms", d)
+	}
+}
+
+func TestGreetWithRetryCancelDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	calls := 0
+	start := time.Now()
+	_, err := GreetWithRetry(ctx, failingGreeter(10, errTransient, &calls), "Ada", RetryPolicy{MaxAttempts: 3, InitialDelay: time.Hour})
+	if err != context.Canceled {
+		t.Errorf("GreetWithRetry cancelled in backoff = %v, want context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Errorf("%d calls, want 1", calls)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("GreetWithRetry returned %v after cancel, want promptly", d)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	const d = time.Second
+	lo, hi := d, d
+	for range 1000 {
+		j := jitter(d)
+		if j < 9*d/10 || j > 11*d/10 {
+			t.Fatalf("jitter(%v) = %v, want within 10%%", d, j)
+		}
+		lo, hi = min(lo, j), max(hi, j)
+	}
+	if lo == d && hi == d {
+		t.Error("jitter never changed the delay")
+	}
+	if got := jitter(0); got != 0 {
+		t.Errorf("jitter(0) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,117 @@
+package greet
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Greeting lengths, in runes, outside which GreetScore applies a penalty.
+const (
+	scoreMinLength = 5
+	scoreMaxLength = 60
+)
+
+// ScoreWeights sets the relative importance of each GreetScore criterion.
+// Weights are normalized, so only their ratios matter.
+type ScoreWeights struct {
+	// Length rewards greetings of 5 to 60 runes.
+	Length float64
+	// Script rewards names written in a script used by the locale, e.g.
+	// Latin for "fr" or kana and kanji for "ja".
+	Script float64
+	// Name rewards the presence of the name. A greeting without the name
+	// always scores zero, whatever the weights.
+	Name float64
+}
+
+// DefaultScoreWeights weighs every criterion equally.
+var DefaultScoreWeights = ScoreWeights{Length: 1, Script: 1, Name: 1}
+
+// WithScoreWeights sets the weights used by GreetScore and ScoreGreeting.
+// Negative weights are rejected; all-zero weights mean DefaultScoreWeights.
+func WithScoreWeights(w ScoreWeights) Option {
+	return func(g *SimpleGreeter) error {
+		if w.Length < 0 || w.Script < 0 || w.Name < 0 {
+			return fmt.Errorf("greet: negative score weight in %+v", w)
+		}
+		g.scoreWeights = w
+		return nil
+	}
+}
+
+// localeScripts lists the scripts expected for names in each built-in
+// locale, by base language subtag.
+var localeScripts = map[string][]*unicode.RangeTable{
+	"en": {unicode.Latin},
+	"es": {unicode.Latin},
+	"fr": {unicode.Latin},
+	"de": {unicode.Latin},
+	"pt": {unicode.Latin},
+	"ja": {unicode.Han, unicode.Hiragana, unicode.Katakana},
+	"zh": {unicode.Han},
+}
+
+// GreetScore returns a deterministic quality score in [0, 1] for the
+// greeting g gives name. See ScoreWeights for the criteria.
+func (g SimpleGreeter) GreetScore(name string) float64 {
+	_, score := g.ScoreGreeting(name)
+	return score
+}
+
+// ScoreGreeting greets name and returns the greeting together with its
+// GreetScore. The name counts as present if the greeting contains it as g
+// renders it, after normalization and g.Format markup.
+func (g SimpleGreeter) ScoreGreeting(name string) (string, float64) {
+	g.Name = name
+	s := g.Greet()
+	if strings.TrimSpace(g.norm.Apply(name, g.Locale)) == "" || !strings.Contains(s, g.addressee()) {
+		return s, 0
+	}
+	w := g.scoreWeights
+	if w == (ScoreWeights{}) {
+		w = DefaultScoreWeights
+	}
+	total := w.Length + w.Script + w.Name
+	_, tag, _ := lookupLocale(g.Locale)
+	base, _, _ := strings.Cut(tag, "-")
+	score := w.Length*lengthScore(s) + w.Script*scriptScore(name, localeScripts[base]) + w.Name
+	return s, score / total
+}
+
+// lengthScore is 1 for greetings of acceptable length, falling off in
+// proportion to how far outside that range s is.
+func lengthScore(s string) float64 {
+	n := utf8.RuneCountInString(s)
+	switch {
+	case n < scoreMinLength:
+		return float64(n) / scoreMinLength
+	case n > scoreMaxLength:
+		return scoreMaxLength / float64(n)
+	}
+	return 1
+}
+
+// scriptScore is the fraction of letters in name that belong to one of
+// scripts. Names without letters, and locales without known scripts, score
+// 1.
+func scriptScore(name string, scripts []*unicode.RangeTable) float64 {
+	if len(scripts) == 0 {
+		return 1
+	}
+	var letters, matched int
+	for _, r := range name {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.IsOneOf(scripts, r) {
+			matched++
+		}
+	}
+	if letters == 0 {
+		return 1
+	}
+	return float64(matched) / float64(letters)
+}
@@ -0,0 +1,63 @@
+package greet
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScoreGreetingNamePresence(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		opts []Option
+		name string
+	}{
+		{"plain", nil, "Alice"},
+		{"title-cased", []Option{WithNormalization(NormOpts{TitleCase: true})}, "alice"},
+		{"HTML-escaped", []Option{WithFormat(FormatHTML)}, "O'Brien"},
+		{"with salutation", []Option{WithSalutation("Dr.")}, "Curie"},
+	} {
+		g, err := NewGreeter(tc.name, tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s, score := g.ScoreGreeting(tc.name); score == 0 {
+			t.Errorf("%s: ScoreGreeting(%q) = %q, 0; want the name to count as present", tc.desc, tc.name, s)
+		}
+	}
+}
+
+func TestScoreGreetingNameMissing(t *testing.T) {
+	g := SimpleGreeter{Template: "Hello there"}
+	if _, score := g.ScoreGreeting("Alice"); score != 0 {
+		t.Errorf("score = %v for a greeting without the name, want 0", score)
+	}
+	if score := (SimpleGreeter{}).GreetScore(""); score != 0 {
+		t.Errorf("score = %v for an empty name, want 0", score)
+	}
+}
+
+func TestScoreGreetingRange(t *testing.T) {
+	long := strings.Repeat("a", 200)
+	for _, w := range []ScoreWeights{
+		DefaultScoreWeights,
+		{Length: 5, Script: 1, Name: 5},
+		{Name: 1},
+		{Length: 1},
+	} {
+		g, err := NewGreeter("x", WithScoreWeights(w), WithLocale("ja"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{"A", "Alice", long, "さくら"} {
+			if score := g.GreetScore(name); score < 0 || score > 1 {
+				t.Errorf("weights %+v: GreetScore(%.10q) = %v, outside [0, 1]", w, name, score)
+			}
+		}
+	}
+}
+
+func TestWithScoreWeightsRejectsNegative(t *testing.T) {
+	if _, err := NewGreeter("x", WithScoreWeights(ScoreWeights{Length: -5, Script: 1, Name: 5})); err == nil {
+		t.Error("NewGreeter accepted a negative score weight")
+	}
+}
@@ -0,0 +1,68 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFlushable is returned by GreetSSE when the ResponseWriter cannot
+// flush.
+var ErrNotFlushable = errors.New("greet: response writer does not implement http.Flusher")
+
+// GreetSSE streams a Server-Sent Event greeting to w for each name received
+// on names, flushing after every event. Greetings that fail are sent as an
+// "error" event. When names is closed or ctx is done it sends a final
+// "close" event and returns. w must implement http.Flusher; otherwise
+// nothing is written and ErrNotFlushable is returned.
+func GreetSSE(ctx context.Context, g Greeter, names <-chan string, w http.ResponseWriter) error {
+	f, ok := w.(http.Flusher)
+	if !ok {
+		return ErrNotFlushable
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	f.Flush()
+
+	for {
+		select {
+		case <-ctx.Done():
+			writeEvent(w, f, "close", "bye")
+			return nil
+		case name, ok := <-names:
+			if !ok {
+				return writeEvent(w, f, "close", "bye")
+			}
+			s, err := GreetName(ctx, g, name)
+			if err != nil {
+				err = writeEvent(w, f, "error", err.Error())
+			} else {
+				err = writeEvent(w, f, "", s)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeEvent writes one event, splitting data into a data field per line.
+func writeEvent(w http.ResponseWriter, f http.Flusher, event, data string) error {
+	var b strings.Builder
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(data, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteByte('\n')
+	if _, err := w.Write([]byte(b.String())); err != nil {
+		return err
+	}
+	f.Flush()
+	return nil
+}
@@ -0,0 +1,80 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// noFlushWriter hides the http.Flusher of the recorder it wraps.
+type noFlushWriter struct {
+	http.ResponseWriter
+}
+
+func TestGreetSSENotFlushable(t *testing.T) {
+	rec := httptest.NewRecorder()
+	names := make(chan string, 1)
+	names <- "Ada"
+	err := GreetSSE(context.Background(), SimpleGreeter{}, names, noFlushWriter{rec})
+	if err != ErrNotFlushable {
+		t.Errorf("GreetSSE = %v, want ErrNotFlushable", err)
+	}
+	if rec.Body.Len() != 0 || len(rec.Header()) != 0 || rec.Flushed {
+		t.Errorf("GreetSSE wrote headers %v and body %q, want nothing", rec.Header(), rec.Body.String())
+	}
+	if len(names) != 1 {
+		t.Error("GreetSSE consumed a name")
+	}
+}
+
+func TestGreetSSE(t *testing.T) {
+	g := contextGreeter(func(ctx context.Context, name string) (string, error) {
+		switch name {
+		case "fail":
+			return "", errors.New("no greeting")
+		case "Multi":
+			return "Hello\nMulti", nil
+		}
+		return "Hello " + name, nil
+	})
+	names := make(chan string, 3)
+	names <- "Ada"
+	names <- "Multi"
+	names <- "fail"
+	close(names)
+
+	rec := httptest.NewRecorder()
+	if err := GreetSSE(context.Background(), g, names, rec); err != nil {
+		t.Fatalf("GreetSSE: %v", err)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Cache-Control = %q, want no-cache", got)
+	}
+	if !rec.Flushed {
+		t.Error("GreetSSE did not flush")
+	}
+	want := "data: Hello Ada\n\n" +
+		"data: Hello\ndata: Multi\n\n" +
+		"event: error\ndata: no greeting\n\n" +
+		"event: close\ndata: bye\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestGreetSSECancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	if err := GreetSSE(ctx, SimpleGreeter{}, make(chan string), rec); err != nil {
+		t.Fatalf("GreetSSE: %v", err)
+	}
+	if got, want := rec.Body.String(), "event: close\ndata: bye\n\n"; got != want {
+		t.Errorf("body after cancel = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+)
+
+// ErrUnknownStrategy is returned for strategy names that were never
+// registered.
+var ErrUnknownStrategy = errors.New("greet: unknown strategy")
+
+// StrategyFactory builds a Greeter from strategy-specific settings.
+type StrategyFactory func(cfg map[string]string) (Greeter, error)
+
+// strategy is a registered factory. Registrations are compared by pointer
+// so StrategyGreeter can tell when a name has been re-registered.
+type strategy struct {
+	factory StrategyFactory
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]*strategy{}
+)
+
+// RegisterStrategy makes a greeting strategy available under name,
+// replacing any strategy already registered there. It is intended to be
+// called from the init function of packages providing strategies, and
+// panics if name is empty or factory is nil.
+func RegisterStrategy(name string, factory StrategyFactory) {
+	if name == "" || factory == nil {
+		panic("greet: RegisterStrategy called with empty name or nil factory")
+	}
+	strategiesMu.Lock()
+	strategies[name] = &strategy{factory: factory}
+	strategiesMu.Unlock()
+}
+
+func lookupStrategy(name string) (*strategy, error) {
+	strategiesMu.RLock()
+	s, ok := strategies[name]
+	strategiesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownStrategy, name)
+	}
+	return s, nil
+}
+
+// StrategyGreeter greets with whichever strategy is registered under its
+// name at the time of the call, rebuilding its greeter if the strategy is
+// re-registered. It is safe for concurrent use.
+type StrategyGreeter struct {
+	name string
+	cfg  map[string]string
+
+	mu      sync.Mutex
+	current *strategy
+	greeter Greeter
+}
+
+var _ GreeterWithError = (*StrategyGreeter)(nil)
+
+// NewStrategyGreeter returns a greeter for the strategy registered under
+// strategyName, built with cfg.
+func NewStrategyGreeter(strategyName string, cfg map[string]string) (*StrategyGreeter, error) {
+	sg := &StrategyGreeter{name: strategyName, cfg: maps.Clone(cfg)}
+	if _, err := sg.resolve(); err != nil {
+		return nil, err
+	}
+	return sg, nil
+}
+
+// resolve returns the greeter for the currently registered strategy.
+func (sg *StrategyGreeter) resolve() (Greeter, error) {
+	s, err := lookupStrategy(sg.name)
+	if err != nil {
+		return nil, err
+	}
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	if s != sg.current {
+		g, err := s.factory(maps.Clone(sg.cfg))
+		if err != nil {
+			return nil, fmt.Errorf("greet: strategy %q: %w", sg.name, err)
+		}
+		sg.current, sg.greeter = s, g
+	}
+	return sg.greeter, nil
+}
+
+// Greet greets with the active strategy. It returns "" if the strategy is
+// no longer available; use GreetContext to see the error.
+func (sg *StrategyGreeter) Greet() string {
+	g, err := sg.resolve()
+	if err != nil {
+		return ""
+	}
+	return g.Greet()
+}
+
+// GreetContext greets name with the active strategy.
+func (sg *StrategyGreeter) GreetContext(ctx context.Context, name string) (string, error) {
+	g, err := sg.resolve()
+	if err != nil {
+		return "", err
+	}
+	return GreetName(ctx, g, name)
+}
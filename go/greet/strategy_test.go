@@ -0,0 +1,114 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestNewStrategyGreeterUnknown(t *testing.T) {
+	sg, err := NewStrategyGreeter("test-never-registered", nil)
+	if !errors.Is(err, ErrUnknownStrategy) || sg != nil {
+		t.Errorf("NewStrategyGreeter(unknown) = %v, %v; want ErrUnknownStrategy", sg, err)
+	}
+}
+
+func TestStrategyGreeterRebuildsOnReregistration(t *testing.T) {
+	const name = "test-rebuild"
+	builds := 0
+	register := func(greeting string) {
+		RegisterStrategy(name, func(cfg map[string]string) (Greeter, error) {
+			builds++
+			return GreeterFunc(func(n string) string { return greeting + cfg["punct"] + " " + n }), nil
+		})
+	}
+	register("Hi")
+	cfg := map[string]string{"punct": ","}
+	sg, err := NewStrategyGreeter(name, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg["punct"] = "!" // the greeter keeps its own copy
+
+	for range 3 {
+		if got, err := sg.GreetContext(context.Background(), "Ada"); got != "Hi, Ada" || err != nil {
+			t.Fatalf("GreetContext = %q, %v; want %q", got, err, "Hi, Ada")
+		}
+	}
+	if builds != 1 {
+		t.Errorf("%d builds for one registration, want 1", builds)
+	}
+
+	register("Yo")
+	if got := sg.Greet(); got != "Yo, " {
+		t.Errorf("Greet() after re-registration = %q, want %q", got, "Yo, ")
+	}
+	if builds != 2 {
+		t.Errorf("%d builds after re-registration, want 2", builds)
+	}
+}
+
+func TestStrategyGreeterFactoryError(t *testing.T) {
+	const name = "test-factory-error"
+	errBad := errors.New("bad config")
+	RegisterStrategy(name, func(cfg map[string]string) (Greeter, error) {
+		if cfg["ok"] == "" {
+			return nil, errBad
+		}
+		return SimpleGreeter{}, nil
+	})
+	if _, err := NewStrategyGreeter(name, nil); !errors.Is(err, errBad) {
+		t.Errorf("NewStrategyGreeter with a failing factory = %v, want %v", err, errBad)
+	}
+	sg, err := NewStrategyGreeter(name, map[string]string{"ok": "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := sg.GreetContext(context.Background(), "Ada"); got != "Hello Ada" || err != nil {
+		t.Errorf("GreetContext = %q, %v; want Hello Ada", got, err)
+	}
+}
+
+func TestStrategyGreeterConcurrent(t *testing.T) {
+	const name = "test-concurrent"
+	RegisterStrategy(name, func(map[string]string) (Greeter, error) { return SimpleGreeter{}, nil })
+	sg, err := NewStrategyGreeter(name, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 50 {
+				sg.GreetContext(context.Background(), "Ada")
+			}
+		})
+	}
+	for range 10 {
+		RegisterStrategy(name, func(map[string]string) (Greeter, error) { return SimpleGreeter{Locale: "fr"}, nil })
+	}
+	wg.Wait()
+	if got, _ := sg.GreetContext(context.Background(), "Ada"); got != "Bonjour Ada" {
+		t.Errorf("GreetContext after concurrent re-registration = %q, want Bonjour Ada", got)
+	}
+}
+
+func TestRegisterStrategyPanics(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		factory StrategyFactory
+	}{
+		{"", func(map[string]string) (Greeter, error) { return SimpleGreeter{}, nil }},
+		{"test-nil-factory", nil},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterStrategy(%q, factory) did not panic", tc.name)
+				}
+			}()
+			RegisterStrategy(tc.name, tc.factory)
+		}()
+	}
+}
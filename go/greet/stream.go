@@ -0,0 +1,35 @@
+package greet
+
+import "context"
+
+// GreetStream greets each name received on in and sends the result to out.
+// It runs in the caller's goroutine; start it with go to run it
+// concurrently. out is closed when in is closed or ctx is done, and sends
+// on out never outlive ctx. Names that fail to greet are skipped.
+func GreetStream(ctx context.Context, g Greeter, in <-chan string, out chan<- string) {
+	defer close(out)
+	for {
+		var name string
+		select {
+		case <-ctx.Done():
+			return
+		case n, ok := <-in:
+			if !ok {
+				return
+			}
+			name = n
+		}
+		s, err := GreetName(ctx, g, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case out <- s:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package greet
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+// BenchmarkGreetStream measures throughput with unbuffered and buffered
+// out channels; each op greets one name.
+func BenchmarkGreetStream(b *testing.B) {
+	for _, size := range []int{0, 1, 64, 1024} {
+		b.Run("out="+strconv.Itoa(size), func(b *testing.B) {
+			in := make(chan string, 1024)
+			out := make(chan string, size)
+			go GreetStream(context.Background(), SimpleGreeter{}, in, out)
+			go func() {
+				for range b.N {
+					in <- "Ada"
+				}
+				close(in)
+			}()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range out {
+			}
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "names/s")
+		})
+	}
+}
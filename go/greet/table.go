@@ -0,0 +1,65 @@
+package greet
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// GreetRow is one row of a GreetTable: the settings a greeting was made
+// with and the greeting itself.
+type GreetRow struct {
+	Name       string
+	Locale     string
+	Salutation string
+	Greeting   string
+}
+
+// GreetTable writes rows to w as an ASCII table with Name, Locale,
+// Salutation and Greeting columns, each as wide as its widest cell. Widths
+// are counted in runes. Write errors are returned wrapped.
+func GreetTable(w io.Writer, rows []GreetRow) error {
+	cells := make([][4]string, 0, len(rows)+1)
+	cells = append(cells, [4]string{"Name", "Locale", "Salutation", "Greeting"})
+	for _, r := range rows {
+		cells = append(cells, [4]string{r.Name, r.Locale, r.Salutation, r.Greeting})
+	}
+	var widths [4]int
+	for _, row := range cells {
+		for i, c := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+
+	var b strings.Builder
+	rule := func() {
+		for _, n := range widths {
+			b.WriteString("+-")
+			b.WriteString(strings.Repeat("-", n))
+			b.WriteByte('-')
+		}
+		b.WriteString("+\n")
+	}
+	line := func(row [4]string) {
+		for i, c := range row {
+			b.WriteString("| ")
+			b.WriteString(c)
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c)+1))
+		}
+		b.WriteString("|\n")
+	}
+	rule()
+	line(cells[0])
+	rule()
+	for _, row := range cells[1:] {
+		line(row)
+	}
+	if len(rows) > 0 {
+		rule()
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("greet: table: %w", err)
+	}
+	return nil
+}
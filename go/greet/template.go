@@ -0,0 +1,127 @@
+package greet
+
+import (
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// TemplateData is the value greeting templates are executed against.
+type TemplateData struct {
+	// Name includes the greeter's salutation and suffix, if any.
+	Name string
+	// Time is the current time in UTC.
+	Time time.Time
+	// Locale is the resolved locale tag.
+	Locale string
+}
+
+// templateCacheSize bounds the number of parsed templates kept. Template
+// source can come from callers, so the cache must not grow without limit.
+const templateCacheSize = 256
+
+// templateCache maps template source to its parsed *template.Template,
+// evicting the least recently used entry when full.
+var templateCache = newTemplateLRU(templateCacheSize)
+
+// GreetTemplate greets name by executing tmpl as a text/template against
+// a TemplateData value. Parse and execution failures are reported as a
+// wrapped template.ExecError. Invalid UTF-8 in the result is replaced
+// with U+FFFD.
+func GreetTemplate(name, tmpl string) (string, error) {
+	return executeTemplate(tmpl, TemplateData{
+		Name:   name,
+		Time:   time.Now().UTC(),
+		Locale: DefaultLocale,
+	})
+}
+
+// GreetTemplate greets g using g.Template.
+func (g SimpleGreeter) GreetTemplate() (string, error) {
+	_, tag, _ := lookupLocale(g.Locale)
+	return executeTemplate(g.Template, g.templateData(tag))
+}
+
+func (g SimpleGreeter) templateData(locale string) TemplateData {
+	return TemplateData{Name: g.addressee(), Time: time.Now().UTC(), Locale: locale}
+}
+
+func executeTemplate(tmpl string, data TemplateData) (string, error) {
+	t, err := compileTemplate(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("greet: template %q: %w", tmpl, template.ExecError{Name: "greet", Err: err})
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("greet: template %q: %w", tmpl, err)
+	}
+	return validUTF8(b.String()), nil
+}
+
+// compileTemplate returns the cached parse of tmpl, parsing it on first use.
+func compileTemplate(tmpl string) (*template.Template, error) {
+	if t, ok := templateCache.get(tmpl); ok {
+		return t, nil
+	}
+	t, err := template.New("greet").Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	return templateCache.add(tmpl, t), nil
+}
+
+// templateLRU is a fixed-size least recently used cache of parsed
+// templates. It is safe for concurrent use.
+type templateLRU struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List // of *templateEntry, most recently used first
+	items map[string]*list.Element
+}
+
+type templateEntry struct {
+	src string
+	t   *template.Template
+}
+
+func newTemplateLRU(size int) *templateLRU {
+	return &templateLRU{size: size, order: list.New(), items: make(map[string]*list.Element)}
+}
+
+func (c *templateLRU) get(src string) (*template.Template, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[src]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*templateEntry).t, true
+}
+
+// add caches t for src and returns the cached template, which is an
+// earlier parse if another goroutine added src first.
+func (c *templateLRU) add(src string, t *template.Template) *template.Template {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[src]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*templateEntry).t
+	}
+	c.items[src] = c.order.PushFront(&templateEntry{src: src, t: t})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*templateEntry).src)
+	}
+	return t
+}
+
+func (c *templateLRU) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,39 @@
+package greet
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestTemplateCacheIsBounded(t *testing.T) {
+	for i := range 2 * templateCacheSize {
+		if _, err := GreetTemplate("Ada", "Hello {{.Name}} #"+strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := templateCache.len(); n > templateCacheSize {
+		t.Errorf("template cache holds %d entries, want at most %d", n, templateCacheSize)
+	}
+}
+
+func TestTemplateLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newTemplateLRU(2)
+	for _, src := range []string{"a", "b"} {
+		tmpl, err := compileTemplate(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.add(src, tmpl)
+	}
+	c.get("a")
+	tmpl, _ := compileTemplate("c")
+	c.add("c", tmpl)
+	if _, ok := c.get("b"); ok {
+		t.Error("b was kept; want it evicted as least recently used")
+	}
+	for _, src := range []string{"a", "c"} {
+		if _, ok := c.get(src); !ok {
+			t.Errorf("%s was evicted; want it kept", src)
+		}
+	}
+}
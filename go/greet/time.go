@@ -0,0 +1,51 @@
+package greet
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeGreeterConfig sets the hour (0-23) at which each part of the day
+// begins. Hours before MorningStart count as evening.
+type TimeGreeterConfig struct {
+	MorningStart   int `json:"morning_start" yaml:"morning_start"`
+	AfternoonStart int `json:"afternoon_start" yaml:"afternoon_start"`
+	EveningStart   int `json:"evening_start" yaml:"evening_start"`
+}
+
+// DefaultTimeGreeterConfig is used when a greeter has no TimeConfig.
+var DefaultTimeGreeterConfig = TimeGreeterConfig{
+	MorningStart:   5,
+	AfternoonStart: 12,
+	EveningStart:   18,
+}
+
+// Phrase returns the greeting phrase for t, or "Hello" if t is zero.
+func (c TimeGreeterConfig) Phrase(t time.Time) string {
+	if t.IsZero() {
+		return "Hello"
+	}
+	switch h := t.Hour(); {
+	case h >= c.MorningStart && h < c.AfternoonStart:
+		return "Good morning"
+	case h >= c.AfternoonStart && h < c.EveningStart:
+		return "Good afternoon"
+	default:
+		return "Good evening"
+	}
+}
+
+// GreetWithTime greets name with a phrase chosen from the time of day of t.
+func GreetWithTime(name string, t time.Time) string {
+	return SimpleGreeter{Name: name}.GreetWithTime(t)
+}
+
+// GreetWithTime greets g with a phrase chosen from the time of day of t,
+// using g.TimeConfig or DefaultTimeGreeterConfig when it is nil.
+func (g SimpleGreeter) GreetWithTime(t time.Time) string {
+	cfg := DefaultTimeGreeterConfig
+	if g.TimeConfig != nil {
+		cfg = *g.TimeConfig
+	}
+	return fmt.Sprintf("%s %s", cfg.Phrase(t), g.addressee())
+}
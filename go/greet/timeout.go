@@ -0,0 +1,48 @@
+package greet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimeout matches, via errors.Is, every *TimeoutError.
+var ErrTimeout = errors.New("greet: timed out")
+
+// TimeoutError reports that greeting Name did not finish within Timeout.
+// It matches both ErrTimeout and context.DeadlineExceeded.
+type TimeoutError struct {
+	Name    string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("greet: greeting %q timed out after %v", e.Name, e.Timeout)
+}
+
+// Is reports whether target is ErrTimeout.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// Unwrap returns context.DeadlineExceeded.
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// GreetWithTimeout is like GreetContext with a context that times out
+// after d. It returns a *TimeoutError if the deadline is hit and an error
+// without greeting anything if d is not positive.
+func GreetWithTimeout(name string, d time.Duration) (string, error) {
+	if d <= 0 {
+		return "", fmt.Errorf("greet: invalid timeout %v", d)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	s, err := GreetContext(ctx, name)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "", &TimeoutError{Name: name, Timeout: d}
+	}
+	return s, err
+}
@@ -0,0 +1,81 @@
+package greet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultMaxNameLength is the name length limit, in bytes, applied by
+// NewGreeter and by strict validation when MaxNameLength is zero.
+const DefaultMaxNameLength = 256
+
+var (
+	// ErrEmptyName is returned when a name is empty or only white space.
+	ErrEmptyName = errors.New("greet: empty name")
+	// ErrNameTooLong is returned when a name exceeds the configured limit.
+	ErrNameTooLong = errors.New("greet: name too long")
+	// ErrInvalidCharacter is returned when a name contains a control
+	// character or is not valid UTF-8.
+	ErrInvalidCharacter = errors.New("greet: invalid character in name")
+)
+
+// GreetStrict greets name after validating it, rejecting names longer
+// than DefaultMaxNameLength.
+func GreetStrict(name string) (string, error) {
+	return SimpleGreeter{Name: name}.GreetStrict()
+}
+
+// GreetStrict validates g.Name against g.MaxNameLength, or
+// DefaultMaxNameLength if that is zero, before greeting it.
+func (g SimpleGreeter) GreetStrict() (string, error) {
+	if err := validateName(g.Name, g.strictMaxNameLength()); err != nil {
+		return "", err
+	}
+	return g.Greet(), nil
+}
+
+// MustGreet is like GreetStrict but panics if name is invalid. It is meant
+// for init functions and test fixtures.
+func MustGreet(name string) string {
+	s, err := GreetStrict(name)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (g SimpleGreeter) strictMaxNameLength() int {
+	if g.MaxNameLength == 0 {
+		return DefaultMaxNameLength
+	}
+	return g.MaxNameLength
+}
+
+// validateName checks name against the strict rules. A max of zero
+// disables the length check.
+func validateName(name string, max int) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrEmptyName
+	}
+	if max > 0 && len(name) > max {
+		return fmt.Errorf("%w: %d bytes, max %d", ErrNameTooLong, len(name), max)
+	}
+	if !utf8.ValidString(name) {
+		return fmt.Errorf("%w: invalid UTF-8", ErrInvalidCharacter)
+	}
+	for i, r := range name {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("%w: %U at byte %d", ErrInvalidCharacter, r, i)
+		}
+	}
+	return nil
+}
+
+// validUTF8 replaces invalid UTF-8 in s with U+FFFD, so that greetings
+// built from untrusted names are always valid UTF-8.
+func validUTF8(s string) string {
+	return strings.ToValidUTF8(s, "\uFFFD")
+}
@@ -0,0 +1,214 @@
+package greet
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultPollInterval is how often a polling GreeterWatcher checks its
+// file.
+const DefaultPollInterval = 2 * time.Second
+
+type watcherConfig struct {
+	interval time.Duration
+	poll     bool
+	logger   *slog.Logger
+}
+
+// WatcherOption configures a GreeterWatcher.
+type WatcherOption func(*watcherConfig)
+
+// WithPollInterval sets how often the file is checked for changes when
+// polling. Values that are not positive are ignored.
+func WithPollInterval(d time.Duration) WatcherOption {
+	return func(c *watcherConfig) {
+		if d > 0 {
+			c.interval = d
+		}
+	}
+}
+
+// WithPolling makes the watcher poll the file instead of using fsnotify,
+// for file systems that do not deliver change events, such as many
+// network file systems.
+func WithPolling() WatcherOption {
+	return func(c *watcherConfig) {
+		c.poll = true
+	}
+}
+
+// WithWatchLogger logs reload failures to l.
+func WithWatchLogger(l *slog.Logger) WatcherOption {
+	return func(c *watcherConfig) {
+		c.logger = l
+	}
+}
+
+// GreeterWatcher keeps a SimpleGreeter in sync with a JSON file on disk.
+// Changes are detected with fsnotify, watching the file's directory so that
+// files replaced by rename are followed; if fsnotify is unavailable the
+// file is polled instead. Either way a reload happens only when the file's
+// content hash changes. When a changed file fails to parse, the error is
+// logged and the last good greeter stays current.
+type GreeterWatcher struct {
+	path    string
+	cfg     watcherConfig
+	current atomic.Value // *SimpleGreeter
+	sum     [sha256.Size]byte
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewGreeterWatcher loads the greeter in path and starts watching it for
+// changes. It returns an error if the initial load fails.
+func NewGreeterWatcher(path string, opts ...WatcherOption) (*GreeterWatcher, error) {
+	w := &GreeterWatcher{
+		path: path,
+		cfg:  watcherConfig{interval: DefaultPollInterval},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(&w.cfg)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.load(data); err != nil {
+		return nil, err
+	}
+	w.sum = sha256.Sum256(data)
+
+	var fw *fsnotify.Watcher
+	if !w.cfg.poll {
+		if fw, err = w.newNotifier(); err != nil {
+			w.logError("fsnotify unavailable, polling greeter config", err)
+		}
+	}
+	go w.run(fw)
+	return w, nil
+}
+
+// Current returns the most recently loaded greeter.
+func (w *GreeterWatcher) Current() Greeter {
+	return w.current.Load().(*SimpleGreeter)
+}
+
+// Close stops watching the file. It is safe to call more than once.
+func (w *GreeterWatcher) Close() error {
+	w.closeOnce.Do(func() { close(w.stop) })
+	<-w.done
+	return nil
+}
+
+func (w *GreeterWatcher) newNotifier() (*fsnotify.Watcher, error) {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Add(filepath.Dir(w.path)); err != nil {
+		fw.Close()
+		return nil, err
+	}
+	return fw, nil
+}
+
+// run watches the file with fw, or polls it if fw is nil or stops
+// delivering events, until Close is called.
+func (w *GreeterWatcher) run(fw *fsnotify.Watcher) {
+	defer close(w.done)
+	if fw != nil {
+		stopped := w.watch(fw)
+		fw.Close()
+		if stopped {
+			return
+		}
+		w.logError("fsnotify stopped, polling greeter config", errors.New("event channel closed"))
+	}
+	w.poll()
+}
+
+// watch reloads the file on every event in its directory. It reports
+// whether it returned because Close was called.
+func (w *GreeterWatcher) watch(fw *fsnotify.Watcher) bool {
+	errs := fw.Errors
+	for {
+		select {
+		case <-w.stop:
+			return true
+		case ev, ok := <-fw.Events:
+			if !ok {
+				return false
+			}
+			if ev.Op == fsnotify.Chmod {
+				continue
+			}
+			w.reload()
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			w.logError("watch greeter config", err)
+		}
+	}
+}
+
+func (w *GreeterWatcher) poll() {
+	t := time.NewTicker(w.cfg.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-t.C:
+			w.reload()
+		}
+	}
+}
+
+// reload loads the file if its content changed since the last attempt.
+// It is only called from the watching goroutine.
+func (w *GreeterWatcher) reload() {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		w.logError("read greeter config", err)
+		return
+	}
+	sum := sha256.Sum256(data)
+	if sum == w.sum {
+		return
+	}
+	w.sum = sum
+	if err := w.load(data); err != nil {
+		w.logError("reload greeter config", err)
+	}
+}
+
+func (w *GreeterWatcher) load(data []byte) error {
+	g := new(SimpleGreeter)
+	if err := json.Unmarshal(data, g); err != nil {
+		return fmt.Errorf("greet: parse %s: %w", w.path, err)
+	}
+	w.current.Store(g)
+	return nil
+}
+
+func (w *GreeterWatcher) logError(msg string, err error) {
+	if w.cfg.logger != nil {
+		w.cfg.logger.Error(msg, slog.String("path", w.path), slog.Any("error", err))
+	}
+}
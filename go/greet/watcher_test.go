@@ -0,0 +1,83 @@
+package greet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitForGreeting polls w until it greets with want or a second passes.
+func waitForGreeting(t *testing.T, w *GreeterWatcher, want string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		got := w.Current().Greet()
+		if got == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Current().Greet() = %q, want %q", got, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestGreeterWatcher(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		opts []WatcherOption
+	}{
+		{"fsnotify", nil},
+		{"polling", []WatcherOption{WithPolling(), WithPollInterval(5 * time.Millisecond)}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "greeter.json")
+			writeConfig(t, path, `{"name":"Ada"}`)
+			w, err := NewGreeterWatcher(path, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer w.Close()
+			waitForGreeting(t, w, "Hello Ada")
+
+			// Same size, written straight away, so mtime and size may
+			// both be unchanged.
+			writeConfig(t, path, `{"name":"Eve"}`)
+			waitForGreeting(t, w, "Hello Eve")
+
+			writeConfig(t, path, `{"name":`)
+			time.Sleep(50 * time.Millisecond)
+			waitForGreeting(t, w, "Hello Eve")
+
+			writeConfig(t, path, `{"name":"Eve","locale":"fr"}`)
+			waitForGreeting(t, w, "Bonjour Eve")
+
+			// Editors and config maps replace the file by rename.
+			tmp := path + ".tmp"
+			writeConfig(t, tmp, `{"name":"Zoe","locale":"de"}`)
+			if err := os.Rename(tmp, path); err != nil {
+				t.Fatal(err)
+			}
+			waitForGreeting(t, w, "Hallo Zoe")
+		})
+	}
+}
+
+func TestNewGreeterWatcherInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "greeter.json")
+	if _, err := NewGreeterWatcher(path); err == nil {
+		t.Error("NewGreeterWatcher succeeded for a missing file")
+	}
+	writeConfig(t, path, "not json")
+	if _, err := NewGreeterWatcher(path); err == nil {
+		t.Error("NewGreeterWatcher succeeded for a malformed file")
+	}
+}
@@ -0,0 +1,184 @@
+// Package grpc serves greeters over gRPC as the greet.Greeter service and
+// provides a client for it. Messages are JSON encoded, so no generated
+// protobuf code is needed.
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/greet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GreetRequest asks the gRPC service to greet Name. An empty Name greets
+// with the server greeter's own Greet.
+type GreetRequest struct {
+	Name string `json:"name"`
+}
+
+// GreetResponse carries one greeting from the gRPC service.
+type GreetResponse struct {
+	Greeting string `json:"greeting"`
+}
+
+// NamesRequest asks the gRPC service to stream a greeting for each name.
+type NamesRequest struct {
+	Names []string `json:"names"`
+}
+
+// grpcCodec encodes messages as JSON so the service needs no generated
+// protobuf code. It is forced on both server and client.
+type grpcCodec struct{}
+
+func (grpcCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (grpcCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (grpcCodec) Name() string                       { return "json" }
+
+// greeterService is the handler type of greeterServiceDesc.
+type greeterService interface {
+	greet(ctx context.Context, name string) (string, error)
+}
+
+var greeterServiceDesc = grpc.ServiceDesc{
+	ServiceName: "greet.Greeter",
+	HandlerType: (*greeterService)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Greet",
+		Handler:    greetUnaryHandler,
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "GreetStream",
+		Handler:       greetStreamHandler,
+		ServerStreams: true,
+	}},
+}
+
+const (
+	grpcGreetMethod  = "/greet.Greeter/Greet"
+	grpcStreamMethod = "/greet.Greeter/GreetStream"
+)
+
+// NewServer returns a gRPC server exposing g as the greet.Greeter
+// service, with a unary Greet and a server-streaming GreetStream method.
+func NewServer(g greet.Greeter, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(append([]grpc.ServerOption{grpc.ForceServerCodec(grpcCodec{})}, opts...)...)
+	s.RegisterService(&greeterServiceDesc, server{g: g})
+	return s
+}
+
+type server struct {
+	g greet.Greeter
+}
+
+func (s server) greet(ctx context.Context, name string) (string, error) {
+	var (
+		greeting string
+		err      error
+	)
+	if name == "" {
+		greeting, err = s.g.Greet(), ctx.Err()
+	} else {
+		greeting, err = greet.GreetName(ctx, s.g, name)
+	}
+	switch {
+	case err == nil:
+		return greeting, nil
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "", status.FromContextError(err).Err()
+	default:
+		return "", status.Error(codes.Internal, err.Error())
+	}
+}
+
+func greetUnaryHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(GreetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		s, err := srv.(greeterService).greet(ctx, req.(*GreetRequest).Name)
+		if err != nil {
+			return nil, err
+		}
+		return &GreetResponse{Greeting: s}, nil
+	}
+	if interceptor == nil {
+		return handler(ctx, in)
+	}
+	return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: grpcGreetMethod}, handler)
+}
+
+func greetStreamHandler(srv any, stream grpc.ServerStream) error {
+	in := new(NamesRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	for _, name := range in.Names {
+		s, err := srv.(greeterService).greet(stream.Context(), name)
+		if err != nil {
+			return err
+		}
+		if err := stream.SendMsg(&GreetResponse{Greeting: s}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Client is a greet.Greeter backed by a remote greet.Greeter service.
+type Client struct {
+	conn grpc.ClientConnInterface
+}
+
+var _ greet.GreeterWithError = (*Client)(nil)
+
+// NewClient returns a greet.Greeter that greets through conn.
+func NewClient(conn *grpc.ClientConn) *Client {
+	return &Client{conn: conn}
+}
+
+// Greet returns the remote greeter's own greeting, or "" if the call fails.
+func (c *Client) Greet() string {
+	s, _ := c.GreetContext(context.Background(), "")
+	return s
+}
+
+// GreetContext greets name with the remote greeter.
+func (c *Client) GreetContext(ctx context.Context, name string) (string, error) {
+	out := new(GreetResponse)
+	if err := c.conn.Invoke(ctx, grpcGreetMethod, &GreetRequest{Name: name}, out, grpc.ForceCodec(grpcCodec{})); err != nil {
+		return "", err
+	}
+	return out.Greeting, nil
+}
+
+// GreetNames greets each of names over a single server stream, returning
+// the greetings received before any error.
+func (c *Client) GreetNames(ctx context.Context, names []string) ([]string, error) {
+	stream, err := c.conn.NewStream(ctx, &greeterServiceDesc.Streams[0], grpcStreamMethod, grpc.ForceCodec(grpcCodec{}))
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(&NamesRequest{Names: names}); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(names))
+	for {
+		resp := new(GreetResponse)
+		if err := stream.RecvMsg(resp); err != nil {
+			if err == io.EOF {
+				return out, nil
+			}
+			return out, err
+		}
+		out = append(out, resp.Greeting)
+	}
+}
@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/greet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves g on an in-memory listener and returns a client for it.
+func dial(t *testing.T, g greet.Greeter) *Client {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := NewServer(g)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewClient(conn)
+}
+
+// failingGreeter fails every named greeting.
+type failingGreeter struct{}
+
+func (failingGreeter) Greet() string { return "fallback" }
+
+func (failingGreeter) GreetContext(context.Context, string) (string, error) {
+	return "", errors.New("boom")
+}
+
+func TestGreet(t *testing.T) {
+	c := dial(t, greet.SimpleGreeter{Name: "Server", Locale: "fr"})
+	got, err := c.GreetContext(context.Background(), "Ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "Bonjour Ada" {
+		t.Errorf("GreetContext = %q, want %q", got, "Bonjour Ada")
+	}
+	if got := c.Greet(); got != "Bonjour Server" {
+		t.Errorf("Greet = %q, want %q", got, "Bonjour Server")
+	}
+}
+
+func TestGreetError(t *testing.T) {
+	c := dial(t, failingGreeter{})
+	_, err := c.GreetContext(context.Background(), "Ada")
+	if status.Code(err) != codes.Internal {
+		t.Errorf("GreetContext error = %v, want code Internal", err)
+	}
+}
+
+func TestGreetCanceled(t *testing.T) {
+	c := dial(t, greet.SimpleGreeter{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GreetContext(ctx, "Ada"); status.Code(err) != codes.Canceled {
+		t.Errorf("GreetContext error = %v, want code Canceled", err)
+	}
+}
+
+func TestGreetNames(t *testing.T) {
+	c := dial(t, greet.SimpleGreeter{Salutation: "Dr."})
+	got, err := c.GreetNames(context.Background(), []string{"Ada", "Grace", "Curie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Hello Dr. Ada", "Hello Dr. Grace", "Hello Dr. Curie"}
+	if !slices.Equal(got, want) {
+		t.Errorf("GreetNames = %q, want %q", got, want)
+	}
+}
+
+func TestGreetNamesEmpty(t *testing.T) {
+	c := dial(t, greet.SimpleGreeter{})
+	got, err := c.GreetNames(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("GreetNames(nil) = %q, want none", got)
+	}
+}
+
+func TestGreetNamesError(t *testing.T) {
+	c := dial(t, failingGreeter{})
+	_, err := c.GreetNames(context.Background(), []string{"Ada"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("GreetNames error = %v, want code Internal", err)
+	}
+}
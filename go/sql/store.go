@@ -0,0 +1,113 @@
+// Package sql persists greeter configurations with database/sql.
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/greet"
+)
+
+// ErrGreeterNotFound is returned by GreeterStore.Load for keys with no
+// saved greeter.
+var ErrGreeterNotFound = errors.New("greet: greeter not found")
+
+// tableNamePattern matches the table names GreeterStore accepts. The name
+// is interpolated into SQL, so it is restricted to plain identifiers.
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// GreeterStore persists greeter configurations, keyed by string, in a SQL
+// table. Configurations are stored as their JSON encoding. The queries
+// work on PostgreSQL and SQLite.
+type GreeterStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewGreeterStore returns a store using table in db. Call Init to create
+// the table if needed.
+func NewGreeterStore(db *sql.DB, table string) (*GreeterStore, error) {
+	if !tableNamePattern.MatchString(table) {
+		return nil, fmt.Errorf("greet: store: invalid table name %q", table)
+	}
+	return &GreeterStore{db: db, table: table}, nil
+}
+
+// Init creates the store's table if it does not exist.
+func (s *GreeterStore) Init(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+s.table+` (
+	greeter_key TEXT PRIMARY KEY,
+	config TEXT NOT NULL
+)`)
+	if err != nil {
+		return fmt.Errorf("greet: store: init: %w", err)
+	}
+	return nil
+}
+
+// Save stores g's configuration under key, replacing any saved there.
+func (s *GreeterStore) Save(ctx context.Context, key string, g *greet.SimpleGreeter) error {
+	if key == "" {
+		return greet.ErrEmptyKey
+	}
+	data, err := g.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO `+s.table+` (greeter_key, config) VALUES ($1, $2)
+ON CONFLICT (greeter_key) DO UPDATE SET config = excluded.config`, key, string(data))
+	if err != nil {
+		return fmt.Errorf("greet: store: save %q: %w", key, err)
+	}
+	return nil
+}
+
+// Load returns the greeter saved under key, or an error wrapping
+// ErrGreeterNotFound if there is none.
+func (s *GreeterStore) Load(ctx context.Context, key string) (*greet.SimpleGreeter, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT config FROM `+s.table+` WHERE greeter_key = $1`, key).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %q", ErrGreeterNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("greet: store: load %q: %w", key, err)
+	}
+	g := new(greet.SimpleGreeter)
+	if err := g.UnmarshalJSON([]byte(data)); err != nil {
+		return nil, fmt.Errorf("greet: store: load %q: %w", key, err)
+	}
+	return g, nil
+}
+
+// Delete removes the greeter saved under key, if any.
+func (s *GreeterStore) Delete(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM `+s.table+` WHERE greeter_key = $1`, key); err != nil {
+		return fmt.Errorf("greet: store: delete %q: %w", key, err)
+	}
+	return nil
+}
+
+// List returns the saved keys in sorted order.
+func (s *GreeterStore) List(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT greeter_key FROM `+s.table+` ORDER BY greeter_key`)
+	if err != nil {
+		return nil, fmt.Errorf("greet: store: list: %w", err)
+	}
+	defer rows.Close()
+	keys := []string{}
+	for rows.Next() {
+		var k string
+		if err := rows.Scan(&k); err != nil {
+			return nil, fmt.Errorf("greet: store: list: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("greet: store: list: %w", err)
+	}
+	return keys, nil
+}
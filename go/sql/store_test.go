@@ -0,0 +1,138 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/greet"
+	_ "modernc.org/sqlite"
+)
+
+func newTestStore(t *testing.T) *GreeterStore {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each connection to :memory: is a separate database.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	s, err := NewGreeterStore(db, "greeters")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Init(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestNewGreeterStoreRejectsInvalidTable(t *testing.T) {
+	for _, table := range []string{"", "1greeters", "greeters; DROP TABLE x", "a.b"} {
+		if _, err := NewGreeterStore(nil, table); err == nil {
+			t.Errorf("NewGreeterStore(%q) succeeded, want error", table)
+		}
+	}
+}
+
+func TestInitIsIdempotent(t *testing.T) {
+	s := newTestStore(t)
+	if err := s.Init(context.Background()); err != nil {
+		t.Fatalf("second Init: %v", err)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	want := &greet.SimpleGreeter{
+		Name:          "Curie",
+		Locale:        "fr",
+		Salutation:    "Dr.",
+		RandomPhrases: []string{"Salut %s"},
+		Format:        greet.FormatMarkdown,
+		TimeConfig:    &greet.TimeGreeterConfig{MorningStart: 6, AfternoonStart: 12, EveningStart: 19},
+	}
+	if err := s.Save(ctx, "tenant-a", want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Load(ctx, "tenant-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("Load = %+v, want %+v", got, want)
+	}
+}
+
+func TestSaveReplaces(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	if err := s.Save(ctx, "k", &greet.SimpleGreeter{Name: "Ada", Locale: "en"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(ctx, "k", &greet.SimpleGreeter{Name: "Ada", Locale: "de"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Load(ctx, "k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Locale != "de" {
+		t.Errorf("Locale = %q after second Save, want %q", got.Locale, "de")
+	}
+}
+
+func TestSaveEmptyKey(t *testing.T) {
+	s := newTestStore(t)
+	if err := s.Save(context.Background(), "", &greet.SimpleGreeter{}); !errors.Is(err, greet.ErrEmptyKey) {
+		t.Errorf("Save with empty key = %v, want ErrEmptyKey", err)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	s := newTestStore(t)
+	if _, err := s.Load(context.Background(), "nope"); !errors.Is(err, ErrGreeterNotFound) {
+		t.Errorf("Load of missing key = %v, want ErrGreeterNotFound", err)
+	}
+}
+
+func TestDeleteAndList(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	for _, key := range []string{"c", "a", "b"} {
+		if err := s.Save(ctx, key, &greet.SimpleGreeter{Name: key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Delete(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, "missing"); err != nil {
+		t.Errorf("Delete of missing key: %v", err)
+	}
+	keys, err := s.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "c"}; !slices.Equal(keys, want) {
+		t.Errorf("List = %q, want %q", keys, want)
+	}
+	if _, err := s.Load(ctx, "b"); !errors.Is(err, ErrGreeterNotFound) {
+		t.Errorf("Load after Delete = %v, want ErrGreeterNotFound", err)
+	}
+}
+
+func TestListEmpty(t *testing.T) {
+	keys, err := newTestStore(t).List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys == nil || len(keys) != 0 {
+		t.Errorf("List = %#v, want empty non-nil slice", keys)
+	}
+}
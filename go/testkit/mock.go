@@ -0,0 +1,87 @@
+// Package testkit provides test doubles and assertions for code that uses
+// package greet.
+package testkit
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/izabela-matusiewicz-xtb/kit/go/greet"
+)
+
+// MockGreeter is a greet.GreeterWithError for tests that returns
+// ReturnValue and records the names it was asked to greet. It is safe for
+// concurrent use; set ReturnValue before sharing it.
+type MockGreeter struct {
+	ReturnValue string
+
+	mu    sync.Mutex
+	names []string
+}
+
+var _ greet.GreeterWithError = (*MockGreeter)(nil)
+
+// Greet records a call with an empty name and returns m.ReturnValue.
+func (m *MockGreeter) Greet() string {
+	m.record("")
+	return m.ReturnValue
+}
+
+// GreetContext records a call for name and returns m.ReturnValue, or
+// ctx.Err() without recording anything if ctx is done.
+func (m *MockGreeter) GreetContext(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	m.record(name)
+	return m.ReturnValue, nil
+}
+
+func (m *MockGreeter) record(name string) {
+	m.mu.Lock()
+	m.names = append(m.names, name)
+	m.mu.Unlock()
+}
+
+// CallCount returns the number of recorded calls.
+func (m *MockGreeter) CallCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.names)
+}
+
+// LastName returns the name of the most recent call, or "" if there were
+// none.
+func (m *MockGreeter) LastName() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.names) == 0 {
+		return ""
+	}
+	return m.names[len(m.names)-1]
+}
+
+// Names returns the names of all recorded calls in order.
+func (m *MockGreeter) Names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.names)
+}
+
+// AssertGreeted fails t unless g was called for name.
+func AssertGreeted(t testing.TB, g *MockGreeter, name string) {
+	t.Helper()
+	if names := g.Names(); !slices.Contains(names, name) {
+		t.Errorf("MockGreeter: %q was not greeted; got calls for %q", name, names)
+	}
+}
+
+// AssertGreetCount fails t unless g was called exactly n times.
+func AssertGreetCount(t testing.TB, g *MockGreeter, n int) {
+	t.Helper()
+	if got := g.CallCount(); got != n {
+		t.Errorf("MockGreeter: got %d calls, want %d", got, n)
+	}
+}